@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PublisherAPIKey describes a publisher API key. The secret itself is only
+// returned once, in CreatedAPIKey.
+type PublisherAPIKey struct {
+	ID          string       `json:"id"`
+	PublisherID string       `json:"publisher_id"`
+	Name        string       `json:"name"`
+	Prefix      string       `json:"prefix"`
+	Permissions []Permission `json:"permissions"`
+	CreatedByID uint         `json:"created_by_id"`
+	LastUsedAt  *time.Time   `json:"last_used_at"`
+	ExpiresAt   *time.Time   `json:"expires_at"`
+	RevokedAt   *time.Time   `json:"revoked_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Has reports whether the key grants permission p.
+func (k *PublisherAPIKey) Has(p Permission) bool {
+	for _, kp := range k.Permissions {
+		if kp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether the key is neither revoked nor expired at t.
+func (k *PublisherAPIKey) Active(t time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || t.Before(*k.ExpiresAt)
+}
+
+// CreatedAPIKey is returned when a key is created or rotated. Secret is the
+// full key value and cannot be retrieved again.
+type CreatedAPIKey struct {
+	PublisherAPIKey
+	Secret string `json:"secret"`
+}
+
+// CreateAPIKeyRequest is the request body for creating a publisher API key.
+type CreateAPIKeyRequest struct {
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+}
+
+// UpdateAPIKeyRequest is the request body for changing a key's permissions.
+type UpdateAPIKeyRequest struct {
+	Permissions []Permission `json:"permissions"`
+}
+
+// ListAPIKeys returns the API keys of a publisher.
+func (c *Client) ListAPIKeys(ctx context.Context, publisherSlug string) ([]PublisherAPIKey, error) {
+	var keys []PublisherAPIKey
+	path := fmt.Sprintf("/v1/publishers/%s/api-keys", publisherSlug)
+	if err := c.get(ctx, path, &keys); err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []PublisherAPIKey{}
+	}
+	return keys, nil
+}
+
+// CreateAPIKey creates a publisher API key and returns it with its secret.
+// Requires the manage_members permission.
+func (c *Client) CreateAPIKey(ctx context.Context, publisherSlug string, req *CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+	var key CreatedAPIKey
+	path := fmt.Sprintf("/v1/publishers/%s/api-keys", publisherSlug)
+	if err := c.post(ctx, path, req, &key); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	return &key, nil
+}
+
+// RotateAPIKey replaces the secret of a key, keeping its name and permissions.
+// The previous secret stops working immediately. Requires the manage_members
+// permission.
+func (c *Client) RotateAPIKey(ctx context.Context, publisherSlug, keyID string) (*CreatedAPIKey, error) {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+	var key CreatedAPIKey
+	path := fmt.Sprintf("/v1/publishers/%s/api-keys/%s/rotate", publisherSlug, keyID)
+	if err := c.post(ctx, path, nil, &key); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	return &key, nil
+}
+
+// SetAPIKeyPermissions replaces the permissions granted to a key. Requires
+// the manage_members permission.
+func (c *Client) SetAPIKeyPermissions(ctx context.Context, publisherSlug, keyID string, permissions []Permission) (*PublisherAPIKey, error) {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+	var key PublisherAPIKey
+	path := fmt.Sprintf("/v1/publishers/%s/api-keys/%s", publisherSlug, keyID)
+	if err := c.patch(ctx, path, &UpdateAPIKeyRequest{Permissions: permissions}, &key); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey revokes a publisher API key. Requires the manage_members
+// permission.
+func (c *Client) RevokeAPIKey(ctx context.Context, publisherSlug, keyID string) error {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/v1/publishers/%s/api-keys/%s", publisherSlug, keyID)
+	if err := c.del(ctx, path, nil); err != nil {
+		return asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func fakeAPIKeysAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	key := map[string]interface{}{
+		"id":           "key-1",
+		"publisher_id": "pub-1",
+		"name":         "ci",
+		"prefix":       "ovk_ab12",
+		"permissions":  []string{"publish"},
+		"last_used_at": "2026-02-20T10:00:00Z",
+		"expires_at":   "2027-01-01T00:00:00Z",
+	}
+
+	mux.HandleFunc("/v1/publishers/omniview/api-keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, map[string]interface{}{"success": true, "data": []interface{}{key}})
+		case http.MethodPost:
+			var body CreateAPIKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, map[string]interface{}{
+				"success": true,
+				"data": map[string]interface{}{
+					"id":          "key-2",
+					"name":        body.Name,
+					"permissions": body.Permissions,
+					"secret":      "ovk_secret_value",
+				},
+			})
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/v1/publishers/omniview/api-keys/key-1/rotate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": "key-1", "name": "ci", "secret": "ovk_rotated"},
+		})
+	})
+
+	mux.HandleFunc("/v1/publishers/omniview/api-keys/key-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			var body UpdateAPIKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"id": "key-1", "permissions": body.Permissions},
+			})
+		case http.MethodDelete:
+			writeJSON(w, map[string]interface{}{"success": true})
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestClient_ListAPIKeys(t *testing.T) {
+	srv := fakeAPIKeysAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	keys, err := c.ListAPIKeys(context.Background(), "omniview")
+	if err != nil {
+		t.Fatalf("ListAPIKeys() error: %v", err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("expected 1 key, got %d", len(keys))
+	}
+	k := keys[0]
+	if !k.Has(PermissionPublish) || k.Has(PermissionManageMembers) {
+		t.Fatalf("unexpected permissions: %v", k.Permissions)
+	}
+	if k.LastUsedAt == nil || k.ExpiresAt == nil {
+		t.Fatal("expected last-used and expiry times")
+	}
+	if !k.Active(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("expected key to be active before expiry")
+	}
+	if k.Active(time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("expected key to be inactive after expiry")
+	}
+}
+
+func TestClient_CreateAPIKey(t *testing.T) {
+	srv := fakeAPIKeysAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	key, err := c.CreateAPIKey(context.Background(), "omniview", &CreateAPIKeyRequest{
+		Name:        "deploy",
+		Permissions: []Permission{PermissionPublish, PermissionManagePlugins},
+	})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error: %v", err)
+	}
+	if key.Secret != "ovk_secret_value" {
+		t.Fatalf("expected secret, got %q", key.Secret)
+	}
+	if key.Name != "deploy" || len(key.Permissions) != 2 {
+		t.Fatalf("unexpected key: %+v", key.PublisherAPIKey)
+	}
+}
+
+func TestClient_RotateAPIKey(t *testing.T) {
+	srv := fakeAPIKeysAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	key, err := c.RotateAPIKey(context.Background(), "omniview", "key-1")
+	if err != nil {
+		t.Fatalf("RotateAPIKey() error: %v", err)
+	}
+	if key.Secret != "ovk_rotated" {
+		t.Fatalf("expected rotated secret, got %q", key.Secret)
+	}
+}
+
+func TestClient_SetAPIKeyPermissions(t *testing.T) {
+	srv := fakeAPIKeysAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	key, err := c.SetAPIKeyPermissions(context.Background(), "omniview", "key-1", []Permission{PermissionManageMembers})
+	if err != nil {
+		t.Fatalf("SetAPIKeyPermissions() error: %v", err)
+	}
+	if !key.Has(PermissionManageMembers) {
+		t.Fatalf("expected manage_members, got %v", key.Permissions)
+	}
+}
+
+func TestClient_RevokeAPIKey(t *testing.T) {
+	srv := fakeAPIKeysAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if err := c.RevokeAPIKey(context.Background(), "omniview", "key-1"); err != nil {
+		t.Fatalf("RevokeAPIKey() error: %v", err)
+	}
+}
+
+func TestClient_apiKeyManagement_serverDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "forbidden"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("tok"))
+	ctx := context.Background()
+
+	checks := map[string]error{}
+	_, checks["create"] = c.CreateAPIKey(ctx, "omniview", &CreateAPIKeyRequest{Name: "ci"})
+	_, checks["rotate"] = c.RotateAPIKey(ctx, "omniview", "key-1")
+	_, checks["permissions"] = c.SetAPIKeyPermissions(ctx, "omniview", "key-1", []Permission{PermissionPublish})
+	checks["revoke"] = c.RevokeAPIKey(ctx, "omniview", "key-1")
+	for name, err := range checks {
+		var permErr *PermissionError
+		if !errors.As(err, &permErr) || permErr.Permission != PermissionManageMembers {
+			t.Errorf("%s: expected PermissionError for manage_members, got %v", name, err)
+		}
+	}
+}
+
+func TestPublisherAccess_Has(t *testing.T) {
+	a := &PublisherAccess{CanPublish: true, CanManageMembers: true}
+	if !a.Has(PermissionPublish) || a.Has(PermissionManagePlugins) || !a.Has(PermissionManageMembers) {
+		t.Fatalf("unexpected Has results for %+v", a)
+	}
+	if a.Has(Permission("unknown")) {
+		t.Fatal("expected unknown permission to be denied")
+	}
+}
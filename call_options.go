@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption overrides client settings for a single call. Attach call options
+// to a context with WithCallOptions; every Client method honours them.
+type CallOption func(*callOptions)
+
+// RetryPolicy controls how a call is retried after transient failures.
+// Only idempotent methods (GET, PUT, DELETE) are retried; network errors and
+// 429, 502, 503 and 504 responses are considered transient.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialBackoff is the wait before the second attempt. It doubles after
+	// each further attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+}
+
+type callOptions struct {
+	timeout time.Duration
+	headers http.Header
+	token   string
+	apiKey  string
+	noCache bool
+	retry   *RetryPolicy
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx carrying opts. Options already present
+// in ctx are kept unless overridden.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	co := callOptionsFrom(ctx).clone()
+	for _, o := range opts {
+		o(co)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, co)
+}
+
+// CallTimeout sets the timeout for the call, replacing the client-wide timeout.
+func CallTimeout(d time.Duration) CallOption {
+	return func(co *callOptions) { co.timeout = d }
+}
+
+// CallHeader adds a header to the call.
+func CallHeader(key, value string) CallOption {
+	return func(co *callOptions) { co.headers.Add(key, value) }
+}
+
+// CallToken authenticates the call with token instead of the client's credentials.
+func CallToken(token string) CallOption {
+	return func(co *callOptions) { co.token, co.apiKey = token, "" }
+}
+
+// CallAPIKey authenticates the call with key instead of the client's credentials.
+func CallAPIKey(key string) CallOption {
+	return func(co *callOptions) { co.apiKey, co.token = key, "" }
+}
+
+// CallNoCache asks the server and any intermediaries to bypass caches.
+func CallNoCache() CallOption {
+	return func(co *callOptions) { co.noCache = true }
+}
+
+// CallRetry retries the call according to p.
+func CallRetry(p RetryPolicy) CallOption {
+	return func(co *callOptions) { co.retry = &p }
+}
+
+// callOptionsFrom returns the call options carried by ctx, or empty options.
+func callOptionsFrom(ctx context.Context) *callOptions {
+	if co, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok {
+		return co
+	}
+	return &callOptions{headers: http.Header{}}
+}
+
+func (co *callOptions) clone() *callOptions {
+	cp := *co
+	cp.headers = co.headers.Clone()
+	if cp.headers == nil {
+		cp.headers = http.Header{}
+	}
+	if co.retry != nil {
+		r := *co.retry
+		cp.retry = &r
+	}
+	return &cp
+}
+
+// overridesCredentials reports whether the call authenticates with its own
+// token or API key instead of the client's credentials.
+func (co *callOptions) overridesCredentials() bool {
+	return co.token != "" || co.apiKey != ""
+}
+
+// applyHeaders sets the call's extra headers and cache directives on req.
+func (co *callOptions) applyHeaders(req *http.Request) {
+	for k, vs := range co.headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if co.noCache {
+		req.Header.Set("Cache-Control", "no-cache")
+		req.Header.Set("Pragma", "no-cache")
+	}
+}
+
+// attempts returns how many times a request with method may be sent.
+func (co *callOptions) attempts(method string) int {
+	if co.retry == nil || co.retry.MaxAttempts < 1 {
+		return 1
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return co.retry.MaxAttempts
+	}
+	return 1
+}
+
+// backoff returns the wait before the given retry (1 for the first retry).
+func (co *callOptions) backoff(retry int) time.Duration {
+	d := co.retry.InitialBackoff
+	for i := 1; i < retry; i++ {
+		if co.retry.MaxBackoff > 0 && d >= co.retry.MaxBackoff {
+			break
+		}
+		d *= 2
+	}
+	if co.retry.MaxBackoff > 0 && d > co.retry.MaxBackoff {
+		return co.retry.MaxBackoff
+	}
+	return d
+}
+
+// retryableStatus reports whether a response status is worth retrying.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// httpClientFor returns the HTTP client for a call. A per-call timeout is
+// applied to a shallow copy so the transport is still shared.
+func (c *Client) httpClientFor(co *callOptions) *http.Client {
+	if co.timeout <= 0 {
+		return c.httpClient
+	}
+	hc := *c.httpClient
+	hc.Timeout = co.timeout
+	return &hc
+}
@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCallOptions_headersAndAuthOverride(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("client-key"))
+	ctx := WithCallOptions(context.Background(),
+		CallHeader("X-Tenant", "acme"),
+		CallToken("tenant-token"),
+		CallNoCache(),
+	)
+	if _, err := c.Health(ctx); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+
+	if got.Get("X-Tenant") != "acme" {
+		t.Fatalf("expected X-Tenant header, got %q", got.Get("X-Tenant"))
+	}
+	if got.Get("Authorization") != "Bearer tenant-token" {
+		t.Fatalf("expected per-call token, got %q", got.Get("Authorization"))
+	}
+	if got.Get("X-API-Key") != "" {
+		t.Fatalf("expected client API key to be overridden, got %q", got.Get("X-API-Key"))
+	}
+	if got.Get("Cache-Control") != "no-cache" {
+		t.Fatalf("expected Cache-Control: no-cache, got %q", got.Get("Cache-Control"))
+	}
+}
+
+func TestWithCallOptions_merges(t *testing.T) {
+	ctx := WithCallOptions(context.Background(), CallHeader("A", "1"), CallTimeout(time.Second))
+	ctx = WithCallOptions(ctx, CallHeader("B", "2"))
+
+	co := callOptionsFrom(ctx)
+	if co.headers.Get("A") != "1" || co.headers.Get("B") != "2" {
+		t.Fatalf("expected merged headers, got %v", co.headers)
+	}
+	if co.timeout != time.Second {
+		t.Fatalf("expected timeout to be kept, got %v", co.timeout)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := WithCallOptions(context.Background(), CallTimeout(20*time.Millisecond))
+	if _, err := c.Health(ctx); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if c.httpClient.Timeout != defaultTimeout {
+		t.Fatalf("expected client timeout unchanged, got %v", c.httpClient.Timeout)
+	}
+}
+
+func TestCallRetry(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			writeJSON(w, map[string]interface{}{"success": false, "message": "try again"})
+			return
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := WithCallOptions(context.Background(), CallRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+	hs, err := c.Health(ctx)
+	if err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+	if hs.Status != "ok" {
+		t.Fatalf("expected ok, got %s", hs.Status)
+	}
+	if atomic.LoadInt32(&hits) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", hits)
+	}
+}
+
+func TestCallRetry_skipsNonIdempotent(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "unavailable"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := WithCallOptions(context.Background(), CallRetry(RetryPolicy{MaxAttempts: 3}))
+	err := c.RecordDownload(ctx, "test-plugin", "1.0.0", "linux_amd64")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Fatalf("expected POST not to be retried, got %d attempts", hits)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	co := &callOptions{retry: &RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := co.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	co.retry.InitialBackoff = time.Second
+	if got := co.backoff(1); got != 300*time.Millisecond {
+		t.Errorf("backoff(1) with InitialBackoff above MaxBackoff = %v, want 300ms", got)
+	}
+}
+
+func TestCallRetry_skipsAuthFailures(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	store := &countingStore{CredentialStore: NewFileStore(filepath.Join(t.TempDir(), "creds.json")), err: errors.New("keychain locked")}
+	c := NewClient(WithBaseURL(srv.URL), WithCredentialStore(store))
+	ctx := WithCallOptions(context.Background(), CallRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+	if _, err := c.Health(ctx); !errors.Is(err, store.err) {
+		t.Fatalf("expected credential store error, got %v", err)
+	}
+	if store.gets != 1 || atomic.LoadInt32(&hits) != 0 {
+		t.Fatalf("expected a single credential lookup and no requests, got %d lookups and %d requests", store.gets, hits)
+	}
+}
@@ -0,0 +1,70 @@
+package registry
+
+// Channel is a release channel a plugin version is published to.
+type Channel string
+
+const (
+	// ChannelStable is the default channel. Versions with no channel are
+	// treated as stable.
+	ChannelStable Channel = "stable"
+	// ChannelBeta carries release candidates ahead of stable.
+	ChannelBeta Channel = "beta"
+	// ChannelNightly carries automated builds from the development branch.
+	ChannelNightly Channel = "nightly"
+)
+
+// channelRank orders channels from most to least stable.
+var channelRank = map[Channel]int{
+	ChannelStable:  0,
+	ChannelBeta:    1,
+	ChannelNightly: 2,
+}
+
+// normalize maps the empty channel to ChannelStable.
+func (ch Channel) normalize() Channel {
+	if ch == "" {
+		return ChannelStable
+	}
+	return ch
+}
+
+// Includes reports whether a client following ch is offered versions
+// published to other. Each channel includes every more stable channel, so beta
+// sees stable and beta releases and nightly sees all three. Channels not known
+// to the client only include themselves.
+func (ch Channel) Includes(other Channel) bool {
+	ch, other = ch.normalize(), other.normalize()
+	if ch == other {
+		return true
+	}
+	r, ok := channelRank[ch]
+	o, ok2 := channelRank[other]
+	return ok && ok2 && o <= r
+}
+
+// AllowsPrerelease reports whether prerelease semver versions such as
+// "1.2.0-beta.1" are offered on ch. Only the stable channel excludes them.
+func (ch Channel) AllowsPrerelease() bool {
+	return ch.normalize() != ChannelStable
+}
+
+// WithChannel sets the release channel used by ResolveVersion and
+// CheckForUpdate. The default is ChannelStable.
+func WithChannel(ch Channel) Option {
+	return func(c *Client) { c.channel = ch.normalize() }
+}
+
+// Channel returns the release channel the client follows.
+func (c *Client) Channel() Channel {
+	return c.channel.normalize()
+}
+
+// LatestVersionFor returns the latest version of p published to ch, falling
+// back to LatestVersion for the stable channel or when the registry does not
+// report per-channel versions.
+func (p *Plugin) LatestVersionFor(ch Channel) string {
+	if v, ok := p.LatestVersions[ch.normalize()]; ok && v != "" {
+		return v
+	}
+	return p.LatestVersion
+}
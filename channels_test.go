@@ -0,0 +1,107 @@
+package registry
+
+import (
+	"context"
+	"testing"
+)
+
+func channelVersions() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"version": "1.0.0", "visible": true},
+		{"version": "1.1.0", "visible": true, "channel": "stable"},
+		{"version": "1.2.0-beta.1", "visible": true, "channel": "beta"},
+		{"version": "1.2.0-rc.1", "visible": true},
+		{"version": "1.3.0-nightly.20260101", "visible": true, "channel": "nightly"},
+	}
+}
+
+func TestChannel_Includes(t *testing.T) {
+	tests := []struct {
+		ch, other Channel
+		want      bool
+	}{
+		{ChannelStable, "", true},
+		{ChannelStable, ChannelBeta, false},
+		{ChannelBeta, ChannelStable, true},
+		{ChannelBeta, ChannelNightly, false},
+		{ChannelNightly, ChannelBeta, true},
+		{"canary", "canary", true},
+		{"canary", ChannelStable, false},
+		{ChannelNightly, "canary", false},
+	}
+	for _, tt := range tests {
+		if got := tt.ch.Includes(tt.other); got != tt.want {
+			t.Errorf("%q.Includes(%q) = %v, want %v", tt.ch, tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestClient_ResolveVersion_channels(t *testing.T) {
+	tests := []struct {
+		channel Channel
+		want    string
+	}{
+		{"", "1.1.0"},
+		{ChannelStable, "1.1.0"},
+		{ChannelBeta, "1.2.0-rc.1"},
+		{ChannelNightly, "1.3.0-nightly.20260101"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.channel), func(t *testing.T) {
+			srv := fakeVersionsAPI(t, channelVersions())
+			defer srv.Close()
+
+			c := NewClient(WithBaseURL(srv.URL), WithChannel(tt.channel))
+			v, err := c.ResolveVersion(context.Background(), "test-plugin")
+			if err != nil {
+				t.Fatalf("ResolveVersion() error: %v", err)
+			}
+			if v.Version != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, v.Version)
+			}
+		})
+	}
+}
+
+func TestClient_CheckForUpdate_channel(t *testing.T) {
+	srv := fakeVersionsAPI(t, channelVersions())
+	defer srv.Close()
+	ctx := context.Background()
+
+	stable := NewClient(WithBaseURL(srv.URL))
+	v, err := stable.CheckForUpdate(ctx, "test-plugin", "1.1.0")
+	if err != nil {
+		t.Fatalf("CheckForUpdate() error: %v", err)
+	}
+	if v != nil {
+		t.Fatalf("expected no stable update, got %s", v.Version)
+	}
+
+	beta := stable.Clone(WithChannel(ChannelBeta))
+	v, err = beta.CheckForUpdate(ctx, "test-plugin", "1.2.0-beta.1")
+	if err != nil {
+		t.Fatalf("CheckForUpdate() error: %v", err)
+	}
+	if v == nil || v.Version != "1.2.0-rc.1" {
+		t.Fatalf("expected beta update to 1.2.0-rc.1, got %+v", v)
+	}
+	if stable.Channel() != ChannelStable {
+		t.Fatalf("clone option leaked into parent: %q", stable.Channel())
+	}
+}
+
+func TestPlugin_LatestVersionFor(t *testing.T) {
+	p := &Plugin{
+		LatestVersion:  "1.1.0",
+		LatestVersions: map[Channel]string{ChannelBeta: "1.2.0-beta.1"},
+	}
+	if got := p.LatestVersionFor(ChannelBeta); got != "1.2.0-beta.1" {
+		t.Errorf("beta: got %s", got)
+	}
+	if got := p.LatestVersionFor(ChannelNightly); got != "1.1.0" {
+		t.Errorf("nightly fallback: got %s", got)
+	}
+	if got := p.LatestVersionFor(""); got != "1.1.0" {
+		t.Errorf("stable: got %s", got)
+	}
+}
@@ -3,9 +3,11 @@ package registry
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -14,12 +16,35 @@ const (
 	defaultTimeout = 30 * time.Second
 )
 
-// Client is the registry API client.
+// Client is the registry API client. It is safe for concurrent use.
 type Client struct {
 	baseURL    string
-	token      string
-	apiKey     string
 	httpClient *http.Client
+	credStore  CredentialStore
+	expiryWarn *expiryWarning
+	lockfile   *Lockfile
+	channel    Channel
+	trust      *TrustStore
+	policy     *VerificationPolicy
+
+	installationID string
+	cohorts        []string
+
+	// mu guards the credentials, which may be replaced at runtime.
+	mu          sync.RWMutex
+	token       string
+	apiKey      string
+	tokenSource TokenSource
+
+	// storeMu guards the credentials looked up from credStore, which are
+	// read once and cached.
+	storeMu     sync.Mutex
+	storeLoaded bool
+	storeCreds  *Credentials
+
+	// accessMu guards access, the caller's known permissions per publisher slug.
+	accessMu sync.Mutex
+	access   map[string]PublisherAccess
 }
 
 // Option configures the Client.
@@ -64,6 +89,73 @@ func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
+// SetToken replaces the JWT bearer token used for subsequent requests.
+// It also removes any token source configured with WithTokenSource.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.tokenSource = nil
+	c.forgetAccess()
+}
+
+// SetAPIKey replaces the API key used for subsequent requests.
+func (c *Client) SetAPIKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = key
+	c.forgetAccess()
+}
+
+// ClearCredentials removes the token, API key and token source from the
+// client. A credential store configured with WithCredentialStore is kept.
+func (c *Client) ClearCredentials() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+	c.apiKey = ""
+	c.tokenSource = nil
+	c.forgetAccess()
+	c.forgetStoredCredentials()
+}
+
+// Clone returns a new client with the same settings as c, with opts applied
+// on top. The clone shares c's HTTP client, and therefore its transport and
+// connection pool, unless WithHTTPClient is passed. Cached publisher access is
+// not carried over.
+func (c *Client) Clone(opts ...Option) *Client {
+	c.mu.RLock()
+	clone := &Client{
+		baseURL:        c.baseURL,
+		httpClient:     c.httpClient,
+		credStore:      c.credStore,
+		lockfile:       c.lockfile,
+		channel:        c.channel,
+		trust:          c.trust,
+		policy:         c.policy,
+		installationID: c.installationID,
+		cohorts:        c.cohorts,
+		token:          c.token,
+		apiKey:         c.apiKey,
+		tokenSource:    c.tokenSource,
+	}
+	c.mu.RUnlock()
+	if c.expiryWarn != nil {
+		clone.expiryWarn = &expiryWarning{skew: c.expiryWarn.skew, fn: c.expiryWarn.fn}
+	}
+	for _, o := range opts {
+		o(clone)
+	}
+	return clone
+}
+
+// credentials returns a consistent snapshot of the client's credentials.
+func (c *Client) credentials() (apiKey, token string, ts TokenSource) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiKey, c.token, c.tokenSource
+}
+
 // Health checks the API health endpoint.
 func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
 	var hs HealthStatus
@@ -100,111 +192,139 @@ func (c *Client) del(ctx context.Context, path string, dst interface{}) error {
 
 // doJSON marshals body to JSON and performs the request.
 func (c *Client) doJSON(ctx context.Context, method, path string, body interface{}, dst interface{}) error {
-	var r io.Reader
+	var data []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		var err error
+		data, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshaling request body: %w", err)
 		}
-		r = bytesReader(data)
 	}
-	return c.do(ctx, method, path, r, dst)
+	return c.do(ctx, method, path, data, dst)
 }
 
 // do performs an HTTP request and decodes the API envelope response.
-func (c *Client) do(ctx context.Context, method, path string, body io.Reader, dst interface{}) error {
-	url := c.baseURL + path
-
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	c.setAuthHeader(req)
+func (c *Client) do(ctx context.Context, method, path string, body []byte, dst interface{}) error {
+	_, err := c.doEnvelope(ctx, method, path, body, "application/json", dst)
+	return err
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
-	}
-	defer resp.Body.Close()
+// getList performs a GET and decodes a paginated list response.
+func (c *Client) getList(ctx context.Context, path string, dst interface{}) (*Pagination, error) {
+	return c.doEnvelope(ctx, http.MethodGet, path, nil, "", dst)
+}
 
-	respBody, err := io.ReadAll(resp.Body)
+// doEnvelope performs an HTTP request, decodes the API envelope into dst and
+// returns the envelope's pagination, if any. A nil dst skips decoding.
+// contentType is sent only when body is non-nil.
+func (c *Client) doEnvelope(ctx context.Context, method, path string, body []byte, contentType string, dst interface{}) (*Pagination, error) {
+	resp, respBody, err := c.send(ctx, method, path, body, contentType)
 	if err != nil {
-		return fmt.Errorf("reading response: %w", err)
+		return nil, err
 	}
 
 	if resp.StatusCode >= 400 {
 		var envelope apiResponse
 		if json.Unmarshal(respBody, &envelope) == nil && envelope.Message != "" {
-			return &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
+			return nil, &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
 		}
-		return &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
 	}
 
 	if dst == nil {
-		return nil
+		return nil, nil
 	}
 
 	var envelope apiResponse
 	if err := json.Unmarshal(respBody, &envelope); err != nil {
-		return fmt.Errorf("decoding response envelope: %w", err)
+		return nil, fmt.Errorf("decoding response envelope: %w", err)
 	}
 	if !envelope.Success {
-		return &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
 	}
 	if envelope.Data != nil {
 		if err := json.Unmarshal(envelope.Data, dst); err != nil {
-			return fmt.Errorf("decoding response data: %w", err)
+			return nil, fmt.Errorf("decoding response data: %w", err)
 		}
 	}
-	return nil
+	return envelope.Pagination, nil
 }
 
-// getList performs a GET and decodes a paginated list response.
-func (c *Client) getList(ctx context.Context, path string, dst interface{}) (*Pagination, error) {
-	url := c.baseURL + path
+// send performs an authenticated request and reads the full response body,
+// applying any call options carried by ctx. If the server answers 401 and the
+// token source can be refreshed, the token is refreshed and the request is
+// retried once.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, contentType string) (*http.Response, []byte, error) {
+	co := callOptionsFrom(ctx)
+	attempts := co.attempts(method)
+
+	var (
+		resp      *http.Response
+		respBody  []byte
+		retryable bool
+		err       error
+	)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(co.backoff(attempt - 1)):
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		resp, respBody, retryable, err = c.sendOnce(ctx, co, method, path, body, contentType)
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !co.overridesCredentials() {
+			// Stored credentials may have been replaced since they were cached.
+			c.forgetStoredCredentials()
+			if c.invalidateToken() {
+				resp, respBody, retryable, err = c.sendOnce(ctx, co, method, path, body, contentType)
+			}
+		}
+		if err == nil {
+			retryable = retryableStatus(resp.StatusCode)
+		}
+		if !retryable || ctx.Err() != nil {
+			break
+		}
 	}
-	c.setAuthHeader(req)
+	return resp, respBody, err
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
-	}
-	defer resp.Body.Close()
+// sendOnce sends a single request. retryable reports whether a failure was a
+// network error worth retrying; errors building or authenticating the request
+// are not.
+func (c *Client) sendOnce(ctx context.Context, co *callOptions, method, path string, body []byte, contentType string) (resp *http.Response, respBody []byte, retryable bool, err error) {
+	url := c.baseURL + path
 
-	respBody, err := io.ReadAll(resp.Body)
+	var r io.Reader
+	if body != nil {
+		r = bytesReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, r)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, nil, false, fmt.Errorf("creating request: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		var envelope apiResponse
-		if json.Unmarshal(respBody, &envelope) == nil && envelope.Message != "" {
-			return nil, &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
-		}
-		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
+	if body != nil && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
-
-	var envelope apiResponse
-	if err := json.Unmarshal(respBody, &envelope); err != nil {
-		return nil, fmt.Errorf("decoding response envelope: %w", err)
+	co.applyHeaders(req)
+	if err := c.setAuthHeader(ctx, req); err != nil {
+		return nil, nil, false, err
 	}
-	if !envelope.Success {
-		return nil, &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
+
+	resp, err = c.httpClientFor(co).Do(req)
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("executing request: %w", err)
 	}
-	if envelope.Data != nil {
-		if err := json.Unmarshal(envelope.Data, dst); err != nil {
-			return nil, fmt.Errorf("decoding response data: %w", err)
-		}
+	defer resp.Body.Close()
+
+	respBody, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("reading response: %w", err)
 	}
-	return envelope.Pagination, nil
+	return resp, respBody, false, nil
 }
 
 // bytesReader wraps a byte slice as an io.Reader.
@@ -227,11 +347,88 @@ func (r *bytesReaderImpl) Read(p []byte) (int, error) {
 }
 
 // setAuthHeader sets the appropriate authentication header on the request.
-// API key takes precedence over JWT token.
-func (c *Client) setAuthHeader(req *http.Request) {
-	if c.apiKey != "" {
-		req.Header.Set("X-API-Key", c.apiKey)
-	} else if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+// Credentials from call options override the client's own. API key takes
+// precedence over a token source, which takes precedence over the static JWT
+// token. If none is configured, the credential store (if any) is consulted for
+// the client's base URL; a store error other than ErrCredentialsNotFound fails
+// the request rather than sending it unauthenticated.
+func (c *Client) setAuthHeader(ctx context.Context, req *http.Request) error {
+	if co := callOptionsFrom(ctx); co.apiKey != "" {
+		req.Header.Set("X-API-Key", co.apiKey)
+		return nil
+	} else if co.token != "" {
+		req.Header.Set("Authorization", "Bearer "+co.token)
+		return nil
 	}
+
+	apiKey, token, ts := c.credentials()
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+		return nil
+	}
+
+	if ts != nil {
+		tok, err := ts.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("obtaining token: %w", err)
+		}
+		if tok != nil {
+			token = tok.AccessToken
+		}
+	}
+	if token == "" && c.credStore != nil {
+		creds, err := c.storedCredentials(ctx)
+		if err != nil {
+			return fmt.Errorf("reading stored credentials: %w", err)
+		}
+		if creds != nil {
+			if creds.APIKey != "" {
+				req.Header.Set("X-API-Key", creds.APIKey)
+				return nil
+			}
+			token = creds.Token
+		}
+	}
+	if token != "" {
+		c.checkTokenExpiry(token)
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+// storedCredentials returns the credential store's entry for the client's
+// base URL, or nil if it has none. The store is consulted once and the result
+// cached, so helper processes and encrypted files are not read on every
+// request; errors are not cached.
+func (c *Client) storedCredentials(ctx context.Context) (*Credentials, error) {
+	c.storeMu.Lock()
+	defer c.storeMu.Unlock()
+	if c.storeLoaded {
+		return c.storeCreds, nil
+	}
+
+	var (
+		creds *Credentials
+		err   error
+	)
+	if cs, ok := c.credStore.(ContextCredentialStore); ok {
+		creds, err = cs.GetContext(ctx, c.baseURL)
+	} else {
+		creds, err = c.credStore.Get(c.baseURL)
+	}
+	if errors.Is(err, ErrCredentialsNotFound) {
+		creds, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.storeCreds, c.storeLoaded = creds, true
+	return creds, nil
+}
+
+// forgetStoredCredentials drops the cached credential store lookup.
+func (c *Client) forgetStoredCredentials() {
+	c.storeMu.Lock()
+	defer c.storeMu.Unlock()
+	c.storeCreds, c.storeLoaded = nil, false
 }
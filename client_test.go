@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 )
 
@@ -654,3 +656,95 @@ func TestClient_WithHTTPClient(t *testing.T) {
 		t.Fatalf("Health() with custom client error: %v", err)
 	}
 }
+
+func TestClient_SetCredentialsConcurrently(t *testing.T) {
+	srv := fakeAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				c.SetToken(fmt.Sprintf("token-%d-%d", i, j))
+				c.SetAPIKey(fmt.Sprintf("key-%d-%d", i, j))
+				if j%5 == 0 {
+					c.ClearCredentials()
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if _, err := c.Health(ctx); err != nil {
+					t.Errorf("Health() error: %v", err)
+					return
+				}
+				_ = c.Clone()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_SetTokenAppliesToRequests(t *testing.T) {
+	var mu sync.Mutex
+	var gotAuth, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		gotAuth, gotKey = r.Header.Get("Authorization"), r.Header.Get("X-API-Key")
+		mu.Unlock()
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("old"))
+	c.SetToken("new")
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "Bearer new" {
+		t.Fatalf("expected Bearer new, got %q", gotAuth)
+	}
+
+	c.SetAPIKey("key")
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if gotKey != "key" {
+		t.Fatalf("expected API key to take precedence, got %q", gotKey)
+	}
+
+	c.ClearCredentials()
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "" || gotKey != "" {
+		t.Fatalf("expected no credentials, got auth=%q key=%q", gotAuth, gotKey)
+	}
+}
+
+func TestClient_Clone(t *testing.T) {
+	hc := &http.Client{}
+	c := NewClient(WithBaseURL("https://a.example.com"), WithHTTPClient(hc), WithToken("parent"))
+
+	clone := c.Clone(WithBaseURL("https://b.example.com"))
+	if clone.BaseURL() != "https://b.example.com" {
+		t.Fatalf("expected overridden base URL, got %s", clone.BaseURL())
+	}
+	if c.BaseURL() != "https://a.example.com" {
+		t.Fatalf("expected parent base URL unchanged, got %s", c.BaseURL())
+	}
+	if clone.httpClient != hc {
+		t.Fatal("expected clone to share the HTTP client")
+	}
+
+	clone.SetToken("child")
+	if _, token, _ := c.credentials(); token != "parent" {
+		t.Fatalf("expected parent token unchanged, got %s", token)
+	}
+}
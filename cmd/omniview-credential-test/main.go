@@ -0,0 +1,78 @@
+// Command omniview-credential-test is a reference credential helper for
+// exercising the registry credential helper protocol locally. It keeps
+// credentials in a plaintext JSON file named by OMNIVIEW_CREDENTIAL_TEST_FILE
+// and must not be used for real secrets.
+//
+// Usage:
+//
+//	OMNIVIEW_CREDENTIAL_TEST_FILE=/tmp/creds.json omniview-credential-test get|store|erase|list
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/omniviewdev/registry"
+)
+
+const fileEnv = "OMNIVIEW_CREDENTIAL_TEST_FILE"
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if len(os.Args) != 2 {
+		return fmt.Errorf("usage: %s get|store|erase|list", os.Args[0])
+	}
+	path := os.Getenv(fileEnv)
+	if path == "" {
+		return fmt.Errorf("%s is not set", fileEnv)
+	}
+	store := registry.NewFileStore(path)
+
+	switch os.Args[1] {
+	case "get":
+		var req struct {
+			ServerURL string `json:"server_url"`
+		}
+		if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+			return fmt.Errorf("decoding request: %w", err)
+		}
+		creds, err := store.Get(req.ServerURL)
+		if errors.Is(err, registry.ErrCredentialsNotFound) {
+			return errors.New("credentials not found")
+		}
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(os.Stdout).Encode(creds)
+	case "store":
+		var creds registry.Credentials
+		if err := json.NewDecoder(os.Stdin).Decode(&creds); err != nil {
+			return fmt.Errorf("decoding request: %w", err)
+		}
+		return store.Store(&creds)
+	case "erase":
+		var req struct {
+			ServerURL string `json:"server_url"`
+		}
+		if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+			return fmt.Errorf("decoding request: %w", err)
+		}
+		return store.Erase(req.ServerURL)
+	case "list":
+		servers, err := store.List()
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(os.Stdout).Encode(servers)
+	default:
+		return fmt.Errorf("unknown verb %q", os.Args[1])
+	}
+}
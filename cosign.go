@@ -0,0 +1,189 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// SignerRole identifies who produced an artifact signature.
+type SignerRole string
+
+const (
+	// SignerRegistry marks the registry's own signature.
+	SignerRegistry SignerRole = "registry"
+	// SignerPublisher marks a signature by one of the publisher's registered keys.
+	SignerPublisher SignerRole = "publisher"
+)
+
+// ArtifactSignature is one of the signatures carried by an artifact.
+type ArtifactSignature struct {
+	Role      SignerRole `json:"role"`
+	KeyID     string     `json:"key_id"`
+	Signature string     `json:"signature"` // base64 Ed25519 over the checksum
+}
+
+// AllSignatures returns every signature on a. The single Signature and KeyID
+// fields, set by registries that predate co-signing, are reported as a
+// registry signature unless Signatures already contains it.
+func (a *Artifact) AllSignatures() []ArtifactSignature {
+	sigs := slices.Clone(a.Signatures)
+	if a.Signature != "" && !slices.ContainsFunc(sigs, func(s ArtifactSignature) bool { return s.Signature == a.Signature }) {
+		sigs = append([]ArtifactSignature{{Role: SignerRegistry, KeyID: a.KeyID, Signature: a.Signature}}, sigs...)
+	}
+	return sigs
+}
+
+// CoSignArtifact adds a signature of a.Checksum by priv to a.Signatures,
+// replacing any earlier signature with the same key ID. If keyID is empty the
+// key's default ID is used.
+func CoSignArtifact(priv ed25519.PrivateKey, role SignerRole, keyID string, a *Artifact) error {
+	if a.Checksum == "" {
+		return &FieldError{Field: "checksum", Message: "is required"}
+	}
+	if keyID == "" {
+		keyID = KeyID(priv.Public().(ed25519.PublicKey))
+	}
+	a.Signatures = slices.DeleteFunc(a.Signatures, func(s ArtifactSignature) bool { return s.KeyID == keyID })
+	a.Signatures = append(a.Signatures, ArtifactSignature{Role: role, KeyID: keyID, Signature: SignChecksum(priv, a.Checksum)})
+	return nil
+}
+
+// SignatureRequirement is one clause of a VerificationPolicy: at least
+// Threshold distinct keys from Keys must have signed the artifact.
+type SignatureRequirement struct {
+	// Name labels the requirement in results and errors.
+	Name string
+	// Role, if set, restricts the requirement to signatures with that role.
+	Role SignerRole
+	Keys *TrustStore
+	// Threshold is the number of distinct keys required. Defaults to 1.
+	Threshold int
+}
+
+func (r *SignatureRequirement) threshold() int {
+	return max(r.Threshold, 1)
+}
+
+// VerificationPolicy decides which signatures an artifact must carry. Every
+// requirement must be satisfied.
+type VerificationPolicy struct {
+	Requirements []SignatureRequirement
+}
+
+// CoSignPolicy returns a policy requiring a signature by a registry key and
+// one by any of the publisher's keys, as returned by PublisherTrustStore.
+func CoSignPolicy(registry, publisher *TrustStore) *VerificationPolicy {
+	return &VerificationPolicy{Requirements: []SignatureRequirement{
+		{Name: string(SignerRegistry), Role: SignerRegistry, Keys: registry},
+		{Name: string(SignerPublisher), Role: SignerPublisher, Keys: publisher},
+	}}
+}
+
+// Validate checks that the policy can be satisfied.
+func (p *VerificationPolicy) Validate() error {
+	if len(p.Requirements) == 0 {
+		return &FieldError{Field: "requirements", Message: "must contain at least one requirement"}
+	}
+	var errs []error
+	for i, r := range p.Requirements {
+		if r.Keys == nil {
+			errs = append(errs, &FieldError{Field: fmt.Sprintf("requirements[%d].keys", i), Message: "is required"})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SignerMatch records a signature that satisfied part of a policy.
+type SignerMatch struct {
+	Requirement string
+	Role        SignerRole
+	KeyID       string
+}
+
+// VerificationResult reports which signers matched a policy.
+type VerificationResult struct {
+	Matched []SignerMatch
+}
+
+// KeyIDs returns the IDs of the keys that matched requirement.
+func (r *VerificationResult) KeyIDs(requirement string) []string {
+	var ids []string
+	for _, m := range r.Matched {
+		if m.Requirement == requirement {
+			ids = append(ids, m.KeyID)
+		}
+	}
+	return ids
+}
+
+// PolicyError is returned when an artifact's signatures do not satisfy a
+// requirement of a VerificationPolicy.
+type PolicyError struct {
+	Requirement string
+	Threshold   int
+	Matched     int
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("signature requirement %q not met: %d of %d valid signatures", e.Requirement, e.Matched, e.Threshold)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrInvalidSignature
+}
+
+// Verify checks a's signatures of checksum against the policy. The result
+// lists every signer that matched, and is returned even when a requirement
+// is not met; unmet requirements are reported as *PolicyError values joined
+// into the error.
+func (p *VerificationPolicy) Verify(checksum string, a *Artifact) (*VerificationResult, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	sigs := a.AllSignatures()
+	if len(sigs) == 0 {
+		return &VerificationResult{}, ErrUnsignedArtifact
+	}
+
+	res := &VerificationResult{}
+	var errs []error
+	for _, req := range p.Requirements {
+		var matched []string
+		for _, sig := range sigs {
+			if req.Role != "" && sig.Role != "" && sig.Role != req.Role {
+				continue
+			}
+			id, err := req.Keys.verify(checksum, sig.KeyID, sig.Signature)
+			if err != nil || slices.Contains(matched, id) {
+				continue
+			}
+			matched = append(matched, id)
+			res.Matched = append(res.Matched, SignerMatch{Requirement: req.Name, Role: sig.Role, KeyID: id})
+		}
+		if len(matched) < req.threshold() {
+			errs = append(errs, &PolicyError{Requirement: req.Name, Threshold: req.threshold(), Matched: len(matched)})
+		}
+	}
+	return res, errors.Join(errs...)
+}
+
+// WithVerificationPolicy sets the policy downloaded artifacts must satisfy.
+// It takes precedence over the trust store set with WithTrustStore.
+func WithVerificationPolicy(p *VerificationPolicy) Option {
+	return func(c *Client) { c.policy = p }
+}
+
+// VerifyArtifact checks a's signatures of checksum against the client's
+// verification policy or, if none is set, requires a signature by a key in
+// the client's trust store.
+func (c *Client) VerifyArtifact(checksum string, a *Artifact) (*VerificationResult, error) {
+	p := c.policy
+	if p == nil {
+		p = &VerificationPolicy{Requirements: []SignatureRequirement{
+			{Name: string(SignerRegistry), Role: SignerRegistry, Keys: c.TrustStore()},
+		}}
+	}
+	return p.Verify(checksum, a)
+}
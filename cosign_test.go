@@ -0,0 +1,163 @@
+package registry
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func coSignFixture(t *testing.T) (registry, publisher *TrustStore, a *Artifact) {
+	t.Helper()
+	regPub, regPriv := newTestKey(t)
+	pubA, privA := newTestKey(t)
+	pubB, _ := newTestKey(t)
+
+	var err error
+	if registry, err = NewTrustStore(TrustedKey{ID: "reg-1", PublicKey: regPub}); err != nil {
+		t.Fatal(err)
+	}
+	if publisher, err = NewTrustStore(
+		TrustedKey{ID: "pub-a", PublicKey: pubA},
+		TrustedKey{ID: "pub-b", PublicKey: pubB},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	a = &Artifact{Checksum: "abc123"}
+	if err := SignArtifact(regPriv, "reg-1", a); err != nil {
+		t.Fatal(err)
+	}
+	if err := CoSignArtifact(privA, SignerPublisher, "pub-a", a); err != nil {
+		t.Fatal(err)
+	}
+	return registry, publisher, a
+}
+
+func TestCoSignPolicy_satisfied(t *testing.T) {
+	registry, publisher, a := coSignFixture(t)
+
+	res, err := CoSignPolicy(registry, publisher).Verify(a.Checksum, a)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	want := []SignerMatch{
+		{Requirement: "registry", Role: SignerRegistry, KeyID: "reg-1"},
+		{Requirement: "publisher", Role: SignerPublisher, KeyID: "pub-a"},
+	}
+	if !slices.Equal(res.Matched, want) {
+		t.Fatalf("Matched = %+v, want %+v", res.Matched, want)
+	}
+	if ids := res.KeyIDs("publisher"); !slices.Equal(ids, []string{"pub-a"}) {
+		t.Fatalf("KeyIDs(publisher) = %v", ids)
+	}
+}
+
+func TestCoSignPolicy_missingPublisherSignature(t *testing.T) {
+	registry, publisher, a := coSignFixture(t)
+	a.Signatures = nil
+
+	res, err := CoSignPolicy(registry, publisher).Verify(a.Checksum, a)
+	var pe *PolicyError
+	if !errors.As(err, &pe) || pe.Requirement != "publisher" {
+		t.Fatalf("expected publisher *PolicyError, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected error to wrap ErrInvalidSignature, got %v", err)
+	}
+	if len(res.Matched) != 1 || res.Matched[0].KeyID != "reg-1" {
+		t.Fatalf("expected only the registry signer to match, got %+v", res.Matched)
+	}
+}
+
+func TestCoSignPolicy_revokedPublisherKey(t *testing.T) {
+	registry, publisher, a := coSignFixture(t)
+	if err := publisher.Revoke("pub-a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CoSignPolicy(registry, publisher).Verify(a.Checksum, a); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestCoSignPolicy_roleIsEnforced(t *testing.T) {
+	_, publisher, a := coSignFixture(t)
+	// A publisher signature does not count towards the registry requirement,
+	// even if the registry store happens to trust the key.
+	a.Signature, a.KeyID = "", ""
+
+	if _, err := CoSignPolicy(publisher, publisher).Verify(a.Checksum, a); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerificationPolicy_threshold(t *testing.T) {
+	_, publisher, a := coSignFixture(t)
+	p := &VerificationPolicy{Requirements: []SignatureRequirement{
+		{Name: "maintainers", Keys: publisher, Threshold: 2},
+	}}
+
+	// The same key must not count twice, even when its signature is repeated
+	// without a key ID.
+	dup := a.Signatures[0]
+	dup.KeyID = ""
+	a.Signatures = append(a.Signatures, dup)
+	var pe *PolicyError
+	if _, err := p.Verify(a.Checksum, a); !errors.As(err, &pe) || pe.Matched != 1 || pe.Threshold != 2 {
+		t.Fatalf("expected 1 of 2 signatures, got %v", err)
+	}
+
+	pubC, privC := newTestKey(t)
+	if err := publisher.Add(TrustedKey{ID: "pub-c", PublicKey: pubC}); err != nil {
+		t.Fatal(err)
+	}
+	if err := CoSignArtifact(privC, SignerPublisher, "pub-c", a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Verify(a.Checksum, a); err != nil {
+		t.Fatalf("expected threshold to be met, got %v", err)
+	}
+}
+
+func TestVerificationPolicy_unsignedAndInvalid(t *testing.T) {
+	registry, publisher, _ := coSignFixture(t)
+
+	if _, err := CoSignPolicy(registry, publisher).Verify("abc", &Artifact{Checksum: "abc"}); !errors.Is(err, ErrUnsignedArtifact) {
+		t.Fatalf("expected ErrUnsignedArtifact, got %v", err)
+	}
+	if _, err := (&VerificationPolicy{}).Verify("abc", &Artifact{}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for empty policy, got %v", err)
+	}
+}
+
+func TestArtifact_AllSignatures(t *testing.T) {
+	a := &Artifact{
+		Signature:  "legacy",
+		KeyID:      "reg-1",
+		Signatures: []ArtifactSignature{{Role: SignerPublisher, KeyID: "pub-a", Signature: "co"}},
+	}
+	got := a.AllSignatures()
+	if len(got) != 2 || got[0].Role != SignerRegistry || got[0].Signature != "legacy" {
+		t.Fatalf("AllSignatures = %+v", got)
+	}
+
+	a.Signatures = append(a.Signatures, ArtifactSignature{Role: SignerRegistry, KeyID: "reg-1", Signature: "legacy"})
+	if got := a.AllSignatures(); len(got) != 2 {
+		t.Fatalf("legacy signature duplicated: %+v", got)
+	}
+}
+
+func TestClient_VerifyArtifact(t *testing.T) {
+	registry, publisher, a := coSignFixture(t)
+
+	// Without a policy only the trust store is consulted.
+	c := NewClient(WithTrustStore(registry))
+	if _, err := c.VerifyArtifact(a.Checksum, a); err != nil {
+		t.Fatalf("trust store only: %v", err)
+	}
+
+	c = c.Clone(WithVerificationPolicy(CoSignPolicy(registry, publisher)))
+	a.Signatures = nil
+	if _, err := c.VerifyArtifact(a.Checksum, a); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected co-sign policy to reject, got %v", err)
+	}
+}
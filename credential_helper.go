@@ -0,0 +1,168 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CredentialHelperPrefix is prepended to a helper name to form the executable
+// name looked up on PATH, e.g. "omniview-credential-vault".
+const CredentialHelperPrefix = "omniview-credential-"
+
+// credentialHelperTimeout bounds how long a single helper invocation may run.
+const credentialHelperTimeout = 30 * time.Second
+
+// credentialsNotFoundMessage is the message a helper prints when it has no
+// entry for the requested server.
+const credentialsNotFoundMessage = "credentials not found"
+
+// CredentialHelper is a CredentialStore that delegates to an external
+// executable, in the style of Docker credential helpers.
+//
+// The helper is invoked as `omniview-credential-<name> <verb>` where verb is
+// one of get, store, erase or list. Requests are written to stdin as JSON:
+// get and erase receive {"server_url": "..."}, store receives a Credentials
+// object and list receives nothing. get writes a Credentials object to stdout
+// and list writes a JSON array of server URLs. A helper that has no entry for
+// the server exits non-zero and prints "credentials not found".
+type CredentialHelper struct {
+	name string
+	path string
+}
+
+// NewCredentialHelper returns a CredentialStore backed by the helper
+// executable omniview-credential-<name>, resolved on PATH at each call.
+func NewCredentialHelper(name string) *CredentialHelper {
+	return &CredentialHelper{name: name}
+}
+
+// NewCredentialHelperPath returns a CredentialStore backed by the helper
+// executable at path.
+func NewCredentialHelperPath(path string) *CredentialHelper {
+	return &CredentialHelper{path: path}
+}
+
+// CredentialHelperError is returned when a helper exits unsuccessfully.
+type CredentialHelperError struct {
+	Helper string
+	Verb   string
+	Output string
+	Err    error
+}
+
+func (e *CredentialHelperError) Error() string {
+	if e.Output != "" {
+		return fmt.Sprintf("credential helper %s %s: %v: %s", e.Helper, e.Verb, e.Err, e.Output)
+	}
+	return fmt.Sprintf("credential helper %s %s: %v", e.Helper, e.Verb, e.Err)
+}
+
+func (e *CredentialHelperError) Unwrap() error {
+	return e.Err
+}
+
+type credentialHelperRequest struct {
+	ServerURL string `json:"server_url"`
+}
+
+// Get returns the credentials for serverURL.
+func (h *CredentialHelper) Get(serverURL string) (*Credentials, error) {
+	return h.GetContext(context.Background(), serverURL)
+}
+
+// GetContext is Get, killing the helper if ctx is done first.
+func (h *CredentialHelper) GetContext(ctx context.Context, serverURL string) (*Credentials, error) {
+	out, err := h.run(ctx, "get", &credentialHelperRequest{ServerURL: serverURL})
+	if err != nil {
+		return nil, err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return nil, fmt.Errorf("decoding credential helper output: %w", err)
+	}
+	if creds.ServerURL == "" {
+		creds.ServerURL = serverURL
+	}
+	return &creds, nil
+}
+
+// Store saves creds through the helper.
+func (h *CredentialHelper) Store(creds *Credentials) error {
+	_, err := h.run(context.Background(), "store", creds)
+	return err
+}
+
+// Erase removes the credentials for serverURL. A helper reporting that no
+// entry exists is not treated as an error.
+func (h *CredentialHelper) Erase(serverURL string) error {
+	_, err := h.run(context.Background(), "erase", &credentialHelperRequest{ServerURL: serverURL})
+	if err != nil && !errors.Is(err, ErrCredentialsNotFound) {
+		return err
+	}
+	return nil
+}
+
+// List returns the server URLs known to the helper.
+func (h *CredentialHelper) List() ([]string, error) {
+	out, err := h.run(context.Background(), "list", nil)
+	if err != nil {
+		return nil, err
+	}
+	var servers []string
+	if err := json.Unmarshal(out, &servers); err != nil {
+		return nil, fmt.Errorf("decoding credential helper output: %w", err)
+	}
+	return servers, nil
+}
+
+func (h *CredentialHelper) executable() (string, error) {
+	if h.path != "" {
+		return h.path, nil
+	}
+	path, err := exec.LookPath(CredentialHelperPrefix + h.name)
+	if err != nil {
+		return "", fmt.Errorf("locating credential helper %q: %w", h.name, err)
+	}
+	return path, nil
+}
+
+// run invokes the helper with verb, writing input as JSON to stdin, and
+// returns its stdout. The helper is killed if ctx is done or it runs longer
+// than credentialHelperTimeout.
+func (h *CredentialHelper) run(ctx context.Context, verb string, input interface{}) ([]byte, error) {
+	path, err := h.executable()
+	if err != nil {
+		return nil, err
+	}
+
+	var stdin []byte
+	if input != nil {
+		if stdin, err = json.Marshal(input); err != nil {
+			return nil, fmt.Errorf("marshaling credential helper input: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialHelperTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, verb)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if strings.Contains(output, credentialsNotFoundMessage) {
+			return nil, ErrCredentialsNotFound
+		}
+		return nil, &CredentialHelperError{Helper: path, Verb: verb, Output: output, Err: err}
+	}
+	return stdout.Bytes(), nil
+}
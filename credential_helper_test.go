@@ -0,0 +1,115 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// buildTestHelper compiles cmd/omniview-credential-test into a temp dir, puts
+// it on PATH and points it at a fresh credential file.
+func buildTestHelper(t *testing.T) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping credential helper build in short mode")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, CredentialHelperPrefix+"test")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	out, err := exec.Command(gobin, "build", "-o", bin, "./cmd/omniview-credential-test").CombinedOutput()
+	if err != nil {
+		t.Fatalf("building test helper: %v\n%s", err, out)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("OMNIVIEW_CREDENTIAL_TEST_FILE", filepath.Join(dir, "creds.json"))
+}
+
+func TestCredentialHelper_roundTrip(t *testing.T) {
+	buildTestHelper(t)
+	h := NewCredentialHelper("test")
+
+	if _, err := h.Get("https://example.com"); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Fatalf("expected ErrCredentialsNotFound, got %v", err)
+	}
+	if err := h.Store(&Credentials{ServerURL: "https://example.com", Token: "tok"}); err != nil {
+		t.Fatalf("Store() error: %v", err)
+	}
+	creds, err := h.Get("https://example.com")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if creds.Token != "tok" {
+		t.Fatalf("expected tok, got %s", creds.Token)
+	}
+	servers, err := h.List()
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(servers) != 1 || servers[0] != "https://example.com" {
+		t.Fatalf("unexpected servers: %v", servers)
+	}
+	if err := h.Erase("https://example.com"); err != nil {
+		t.Fatalf("Erase() error: %v", err)
+	}
+	if _, err := h.Get("https://example.com"); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Fatalf("expected ErrCredentialsNotFound after erase, got %v", err)
+	}
+}
+
+func TestCredentialHelper_missingExecutable(t *testing.T) {
+	h := NewCredentialHelper("does-not-exist-anywhere")
+	if _, err := h.Get("https://example.com"); err == nil {
+		t.Fatal("expected error for missing helper")
+	}
+}
+
+func TestClient_credentialHelperAuth(t *testing.T) {
+	buildTestHelper(t)
+
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]string{"status": "ok"},
+		})
+	}))
+	defer srv.Close()
+
+	h := NewCredentialHelper("test")
+	if err := h.Store(&Credentials{ServerURL: srv.URL, Token: "helper-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(WithBaseURL(srv.URL), WithCredentialStore(h))
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+	if gotAuth != "Bearer helper-token" {
+		t.Fatalf("expected helper token, got %q", gotAuth)
+	}
+}
+
+func TestCredentialHelper_GetContextCancelled(t *testing.T) {
+	buildTestHelper(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewCredentialHelper("test").GetContext(ctx, "https://example.com"); err == nil || errors.Is(err, ErrCredentialsNotFound) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+}
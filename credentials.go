@@ -0,0 +1,209 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Credentials holds the secrets used to authenticate against a registry server.
+type Credentials struct {
+	ServerURL string `json:"server_url"`
+	Token     string `json:"token,omitempty"`
+	APIKey    string `json:"api_key,omitempty"`
+}
+
+// CredentialStore persists credentials keyed by registry server URL.
+type CredentialStore interface {
+	// Get returns the credentials for serverURL, or ErrCredentialsNotFound.
+	Get(serverURL string) (*Credentials, error)
+	// Store saves credentials, replacing any existing entry for the same server.
+	Store(creds *Credentials) error
+	// Erase removes the credentials for serverURL. Erasing a missing entry is not an error.
+	Erase(serverURL string) error
+	// List returns the server URLs that have stored credentials.
+	List() ([]string, error)
+}
+
+// ContextCredentialStore is a CredentialStore whose lookups can be cancelled.
+// The client uses GetContext when a store implements it.
+type ContextCredentialStore interface {
+	CredentialStore
+	// GetContext is Get, stopping early if ctx is done.
+	GetContext(ctx context.Context, serverURL string) (*Credentials, error)
+}
+
+// WithCredentialStore sets a store used to look up credentials for the client's
+// base URL when no token or API key is configured directly. The store is read
+// on the first request that needs it and the result is cached until
+// ClearCredentials is called or the server answers 401.
+func WithCredentialStore(store CredentialStore) Option {
+	return func(c *Client) { c.credStore = store }
+}
+
+// FileStore is a plaintext CredentialStore backed by a JSON file.
+// Prefer EncryptedFileStore on shared machines.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore returns a plaintext credential store that reads and writes path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Get returns the credentials for serverURL.
+func (s *FileStore) Get(serverURL string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	creds, ok := entries[serverURL]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialsNotFound, serverURL)
+	}
+	return &creds, nil
+}
+
+// Store saves creds, replacing any existing entry for the same server.
+func (s *FileStore) Store(creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	entries[creds.ServerURL] = *creds
+	return s.save(entries)
+}
+
+// Erase removes the credentials for serverURL.
+func (s *FileStore) Erase(serverURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[serverURL]; !ok {
+		return nil
+	}
+	delete(entries, serverURL)
+	return s.save(entries)
+}
+
+// List returns the server URLs that have stored credentials.
+func (s *FileStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(entries), nil
+}
+
+func (s *FileStore) load() (map[string]Credentials, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Credentials{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading credential file: %w", err)
+	}
+	entries := map[string]Credentials{}
+	if len(data) == 0 {
+		return entries, nil
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("decoding credential file: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *FileStore) save(entries map[string]Credentials) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding credential file: %w", err)
+	}
+	return writeFileAtomic(s.path, data, 0o600)
+}
+
+// MigrateCredentials copies every entry from src into dst and then erases it
+// from src. It is typically used to move a plaintext FileStore into an
+// EncryptedFileStore.
+func MigrateCredentials(dst, src CredentialStore) error {
+	servers, err := src.List()
+	if err != nil {
+		return fmt.Errorf("listing source credentials: %w", err)
+	}
+	for _, server := range servers {
+		creds, err := src.Get(server)
+		if err != nil {
+			return fmt.Errorf("reading credentials for %s: %w", server, err)
+		}
+		if err := dst.Store(creds); err != nil {
+			return fmt.Errorf("storing credentials for %s: %w", server, err)
+		}
+	}
+	for _, server := range servers {
+		if err := src.Erase(server); err != nil {
+			return fmt.Errorf("erasing migrated credentials for %s: %w", server, err)
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and renames
+// it over path, so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("setting file mode: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("replacing file: %w", err)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
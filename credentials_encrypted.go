@@ -0,0 +1,333 @@
+package registry
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedStoreVersion = 1
+	encryptedStoreAD      = "omniview-credentials-v1"
+	minKeyFileSize        = 32
+	kdfSaltSize           = 16
+	aeadKeySize           = 32
+)
+
+// scrypt cost parameters for newly derived keys. Existing files record the
+// parameters they were written with, so these can be raised without breaking them.
+var (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Upper bounds on the scrypt parameters accepted from a file, so a tampered
+// file cannot make key derivation use unbounded memory or time. scrypt needs
+// about 128*N*r bytes, so the limits allow at most 1 GiB.
+const (
+	maxScryptN      = 1 << 20
+	maxScryptR      = 8
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+)
+
+// MasterKey is the secret material from which an EncryptedFileStore derives its
+// encryption key. Create one with NewPassphraseKey or LoadKeyFile.
+type MasterKey struct {
+	secret []byte
+}
+
+// NewPassphraseKey returns a MasterKey derived from a user-supplied passphrase.
+// An empty passphrase yields a key that every store and signing key function
+// rejects with ErrEmptyMasterKey.
+func NewPassphraseKey(passphrase string) MasterKey {
+	return MasterKey{secret: []byte(passphrase)}
+}
+
+// LoadKeyFile reads a MasterKey from a key file. The file must contain at least
+// 32 bytes of secret material.
+func LoadKeyFile(path string) (MasterKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MasterKey{}, fmt.Errorf("reading key file: %w", err)
+	}
+	if len(data) < minKeyFileSize {
+		return MasterKey{}, fmt.Errorf("key file too short: got %d bytes, want at least %d", len(data), minKeyFileSize)
+	}
+	return MasterKey{secret: data}, nil
+}
+
+// kdfParams records how an encryption key was derived from a MasterKey.
+type kdfParams struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// newKDFParams returns scrypt parameters with a fresh random salt.
+func newKDFParams() (kdfParams, error) {
+	salt := make([]byte, kdfSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return kdfParams{}, fmt.Errorf("generating salt: %w", err)
+	}
+	return kdfParams{Name: "scrypt", Salt: salt, N: scryptN, R: scryptR, P: scryptP}, nil
+}
+
+// deriveKey stretches secret into an AEAD key using the recorded parameters.
+func deriveKey(secret []byte, p kdfParams) ([]byte, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptyMasterKey
+	}
+	if p.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation function %q", p.Name)
+	}
+	if p.N <= 1 || p.N > maxScryptN || p.R <= 0 || p.R > maxScryptR || p.P <= 0 || p.P > maxScryptP || 128*p.N*p.R > maxScryptMemory {
+		return nil, fmt.Errorf("scrypt parameters N=%d r=%d p=%d exceed the allowed limits", p.N, p.R, p.P)
+	}
+	key, err := scrypt.Key(secret, p.Salt, p.N, p.R, p.P, aeadKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %w", err)
+	}
+	return key, nil
+}
+
+// sealedBox is an AES-256-GCM ciphertext together with the parameters needed
+// to re-derive its key.
+type sealedBox struct {
+	KDF        kdfParams `json:"kdf"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with key, binding it to ad.
+func seal(key []byte, kdf kdfParams, plaintext, ad []byte) (*sealedBox, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return &sealedBox{
+		KDF:        kdf,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, ad),
+	}, nil
+}
+
+// open decrypts box with key. Authentication failures return ErrDecryptFailed.
+func open(key []byte, box *sealedBox, ad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(box.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce length", ErrDecryptFailed)
+	}
+	plaintext, err := aead.Open(nil, box.Nonce, box.Ciphertext, ad)
+	if err != nil {
+		return nil, ErrDecryptFailed
+	}
+	return plaintext, nil
+}
+
+// encryptedStoreFile is the on-disk layout of an EncryptedFileStore.
+type encryptedStoreFile struct {
+	Version int `json:"version"`
+	sealedBox
+}
+
+// EncryptedFileStore is a CredentialStore that keeps every entry in a single
+// file encrypted with AES-256-GCM under a scrypt-derived key.
+type EncryptedFileStore struct {
+	path string
+	mu   sync.Mutex
+	kdf  kdfParams
+	key  []byte
+}
+
+// OpenEncryptedFileStore opens the encrypted credential file at path, creating
+// it on first write if it does not exist. It returns ErrDecryptFailed if the
+// file exists but mk is not the key it was written with.
+func OpenEncryptedFileStore(path string, mk MasterKey) (*EncryptedFileStore, error) {
+	s := &EncryptedFileStore{path: path}
+
+	f, err := s.readFile()
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		if s.kdf, err = newKDFParams(); err != nil {
+			return nil, err
+		}
+		if s.key, err = deriveKey(mk.secret, s.kdf); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	s.kdf = f.KDF
+	if s.key, err = deriveKey(mk.secret, s.kdf); err != nil {
+		return nil, err
+	}
+	// Decrypt once up front so a wrong key fails here rather than on first use.
+	if _, err := open(s.key, &f.sealedBox, []byte(encryptedStoreAD)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns the credentials for serverURL.
+func (s *EncryptedFileStore) Get(serverURL string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	creds, ok := entries[serverURL]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCredentialsNotFound, serverURL)
+	}
+	return &creds, nil
+}
+
+// Store saves creds, replacing any existing entry for the same server.
+func (s *EncryptedFileStore) Store(creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	entries[creds.ServerURL] = *creds
+	return s.save(entries, s.kdf, s.key)
+}
+
+// Erase removes the credentials for serverURL.
+func (s *EncryptedFileStore) Erase(serverURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[serverURL]; !ok {
+		return nil
+	}
+	delete(entries, serverURL)
+	return s.save(entries, s.kdf, s.key)
+}
+
+// List returns the server URLs that have stored credentials.
+func (s *EncryptedFileStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(entries), nil
+}
+
+// RotateKey re-encrypts every entry under a key derived from mk with a fresh
+// salt. The file is replaced atomically, so a failed rotation leaves the old
+// file readable with the old key.
+func (s *EncryptedFileStore) RotateKey(mk MasterKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	kdf, err := newKDFParams()
+	if err != nil {
+		return err
+	}
+	key, err := deriveKey(mk.secret, kdf)
+	if err != nil {
+		return err
+	}
+	if err := s.save(entries, kdf, key); err != nil {
+		return err
+	}
+	s.kdf, s.key = kdf, key
+	return nil
+}
+
+func (s *EncryptedFileStore) readFile() (*encryptedStoreFile, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading credential file: %w", err)
+	}
+	var f encryptedStoreFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decoding credential file: %w", err)
+	}
+	if f.Version != encryptedStoreVersion {
+		return nil, fmt.Errorf("unsupported credential file version %d", f.Version)
+	}
+	return &f, nil
+}
+
+func (s *EncryptedFileStore) load() (map[string]Credentials, error) {
+	f, err := s.readFile()
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]Credentials{}
+	if f == nil {
+		return entries, nil
+	}
+	plaintext, err := open(s.key, &f.sealedBox, []byte(encryptedStoreAD))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("decoding credentials: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *EncryptedFileStore) save(entries map[string]Credentials, kdf kdfParams, key []byte) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encoding credentials: %w", err)
+	}
+	box, err := seal(key, kdf, plaintext, []byte(encryptedStoreAD))
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(&encryptedStoreFile{Version: encryptedStoreVersion, sealedBox: *box}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding credential file: %w", err)
+	}
+	return writeFileAtomic(s.path, data, 0o600)
+}
@@ -0,0 +1,313 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fastKDF lowers the scrypt cost for the duration of a test.
+func fastKDF(t *testing.T) {
+	t.Helper()
+	original := scryptN
+	scryptN = 1 << 10
+	t.Cleanup(func() { scryptN = original })
+}
+
+func TestFileStore_roundTrip(t *testing.T) {
+	s := NewFileStore(filepath.Join(t.TempDir(), "creds.json"))
+
+	if _, err := s.Get("https://example.com"); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Fatalf("expected ErrCredentialsNotFound, got %v", err)
+	}
+	if err := s.Store(&Credentials{ServerURL: "https://example.com", Token: "tok"}); err != nil {
+		t.Fatalf("Store() error: %v", err)
+	}
+	creds, err := s.Get("https://example.com")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if creds.Token != "tok" {
+		t.Fatalf("expected tok, got %s", creds.Token)
+	}
+	if err := s.Erase("https://example.com"); err != nil {
+		t.Fatalf("Erase() error: %v", err)
+	}
+	if _, err := s.Get("https://example.com"); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Fatalf("expected ErrCredentialsNotFound after erase, got %v", err)
+	}
+}
+
+func TestEncryptedFileStore_roundTrip(t *testing.T) {
+	fastKDF(t)
+	path := filepath.Join(t.TempDir(), "creds.enc")
+
+	s, err := OpenEncryptedFileStore(path, NewPassphraseKey("hunter2"))
+	if err != nil {
+		t.Fatalf("OpenEncryptedFileStore() error: %v", err)
+	}
+	if err := s.Store(&Credentials{ServerURL: "https://example.com", APIKey: "secret-api-key"}); err != nil {
+		t.Fatalf("Store() error: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("secret-api-key")) {
+		t.Fatal("credential file contains plaintext secret")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+
+	reopened, err := OpenEncryptedFileStore(path, NewPassphraseKey("hunter2"))
+	if err != nil {
+		t.Fatalf("reopen error: %v", err)
+	}
+	creds, err := reopened.Get("https://example.com")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if creds.APIKey != "secret-api-key" {
+		t.Fatalf("expected secret-api-key, got %s", creds.APIKey)
+	}
+}
+
+func TestEncryptedFileStore_wrongPassphrase(t *testing.T) {
+	fastKDF(t)
+	path := filepath.Join(t.TempDir(), "creds.enc")
+
+	s, err := OpenEncryptedFileStore(path, NewPassphraseKey("right"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Store(&Credentials{ServerURL: "https://example.com", Token: "tok"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenEncryptedFileStore(path, NewPassphraseKey("wrong")); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed, got %v", err)
+	}
+}
+
+func TestEncryptedFileStore_rotateKey(t *testing.T) {
+	fastKDF(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "creds.enc")
+	keyPath := filepath.Join(dir, "master.key")
+	if err := os.WriteFile(keyPath, bytes.Repeat([]byte{0x42}, 32), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := OpenEncryptedFileStore(path, NewPassphraseKey("old"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Store(&Credentials{ServerURL: "https://example.com", Token: "tok"}); err != nil {
+		t.Fatal(err)
+	}
+
+	newKey, err := LoadKeyFile(keyPath)
+	if err != nil {
+		t.Fatalf("LoadKeyFile() error: %v", err)
+	}
+	if err := s.RotateKey(newKey); err != nil {
+		t.Fatalf("RotateKey() error: %v", err)
+	}
+
+	if _, err := OpenEncryptedFileStore(path, NewPassphraseKey("old")); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected old key to be rejected, got %v", err)
+	}
+	reopened, err := OpenEncryptedFileStore(path, newKey)
+	if err != nil {
+		t.Fatalf("reopen with new key error: %v", err)
+	}
+	creds, err := reopened.Get("https://example.com")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if creds.Token != "tok" {
+		t.Fatalf("expected tok, got %s", creds.Token)
+	}
+}
+
+func TestEncryptedFileStore_rejectsEmptyKey(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := OpenEncryptedFileStore(filepath.Join(dir, "creds.enc"), NewPassphraseKey("")); !errors.Is(err, ErrEmptyMasterKey) {
+		t.Fatalf("expected ErrEmptyMasterKey opening a store, got %v", err)
+	}
+
+	s, err := OpenEncryptedFileStore(filepath.Join(dir, "creds.enc"), NewPassphraseKey("hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RotateKey(MasterKey{}); !errors.Is(err, ErrEmptyMasterKey) {
+		t.Fatalf("expected ErrEmptyMasterKey rotating to an empty key, got %v", err)
+	}
+}
+
+func TestLoadKeyFile_tooShort(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "short.key")
+	if err := os.WriteFile(path, []byte("short"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKeyFile(path); err == nil {
+		t.Fatal("expected error for short key file")
+	}
+}
+
+func TestMigrateCredentials(t *testing.T) {
+	fastKDF(t)
+	dir := t.TempDir()
+
+	plain := NewFileStore(filepath.Join(dir, "creds.json"))
+	for _, server := range []string{"https://a.example.com", "https://b.example.com"} {
+		if err := plain.Store(&Credentials{ServerURL: server, Token: "tok-" + server}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	enc, err := OpenEncryptedFileStore(filepath.Join(dir, "creds.enc"), NewPassphraseKey("pw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateCredentials(enc, plain); err != nil {
+		t.Fatalf("MigrateCredentials() error: %v", err)
+	}
+
+	servers, err := enc.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("expected 2 migrated entries, got %d", len(servers))
+	}
+	left, err := plain.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 0 {
+		t.Fatalf("expected plaintext store to be empty, got %v", left)
+	}
+}
+
+func TestClient_WithCredentialStore(t *testing.T) {
+	var gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-API-Key")
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]string{"status": "ok"},
+		})
+	}))
+	defer srv.Close()
+
+	store := NewFileStore(filepath.Join(t.TempDir(), "creds.json"))
+	if err := store.Store(&Credentials{ServerURL: srv.URL, APIKey: "stored-key"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(WithBaseURL(srv.URL), WithCredentialStore(store))
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+	if gotKey != "stored-key" {
+		t.Fatalf("expected stored-key, got %q", gotKey)
+	}
+}
+
+// countingStore counts lookups made through a CredentialStore.
+type countingStore struct {
+	CredentialStore
+	gets int
+	err  error
+}
+
+func (s *countingStore) Get(serverURL string) (*Credentials, error) {
+	s.gets++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.CredentialStore.Get(serverURL)
+}
+
+func TestClient_credentialStoreLookupCached(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		writeJSON(w, map[string]interface{}{"success": status == http.StatusOK, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	store := &countingStore{CredentialStore: NewFileStore(filepath.Join(t.TempDir(), "creds.json"))}
+	if err := store.Store(&Credentials{ServerURL: srv.URL, Token: "stored-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(WithBaseURL(srv.URL), WithCredentialStore(store))
+	for i := 0; i < 3; i++ {
+		if _, err := c.Health(context.Background()); err != nil {
+			t.Fatalf("Health() error: %v", err)
+		}
+	}
+	if store.gets != 1 {
+		t.Fatalf("expected one store lookup, got %d", store.gets)
+	}
+
+	// A 401 drops the cached entry so the next request reads the store again.
+	status = http.StatusUnauthorized
+	_, _ = c.Health(context.Background())
+	status = http.StatusOK
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if store.gets != 2 {
+		t.Fatalf("expected a fresh lookup after 401, got %d", store.gets)
+	}
+}
+
+func TestClient_credentialStoreError(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	store := &countingStore{CredentialStore: NewFileStore(filepath.Join(t.TempDir(), "creds.json")), err: ErrDecryptFailed}
+	c := NewClient(WithBaseURL(srv.URL), WithCredentialStore(store))
+	if _, err := c.Health(context.Background()); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed, got %v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("request should not be sent unauthenticated, got %d", requests)
+	}
+
+	// A missing entry is not an error.
+	store.err = ErrCredentialsNotFound
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+}
+
+func TestDeriveKey_rejectsExcessiveParameters(t *testing.T) {
+	for _, p := range []kdfParams{
+		{Name: "scrypt", Salt: []byte("salt"), N: 1 << 30, R: 8, P: 1},
+		{Name: "scrypt", Salt: []byte("salt"), N: 1 << 15, R: 1 << 10, P: 1},
+		{Name: "scrypt", Salt: []byte("salt"), N: 1 << 15, R: 8, P: 1 << 20},
+	} {
+		if _, err := deriveKey([]byte("secret"), p); err == nil {
+			t.Errorf("expected %+v to be rejected", p)
+		}
+	}
+}
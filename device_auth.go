@@ -67,8 +67,10 @@ func (c *Client) DeviceToken(ctx context.Context, deviceCode string) (*DeviceAut
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	co := callOptionsFrom(ctx)
+	co.applyHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.httpClientFor(co).Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WellKnownChallengePath is where the HTTP domain verification challenge is served.
+const WellKnownChallengePath = "/.well-known/omniview-verification.txt"
+
+// maxChallengeSize bounds how much of a challenge response is read.
+const maxChallengeSize = 4096
+
+// VerificationMethod is how a publisher proves control of a domain.
+type VerificationMethod string
+
+const (
+	// VerificationDNS proves control with a TXT record.
+	VerificationDNS VerificationMethod = "dns"
+	// VerificationHTTP proves control with a file under /.well-known.
+	VerificationHTTP VerificationMethod = "http"
+)
+
+// DomainVerificationStatus is the state of a domain verification.
+type DomainVerificationStatus string
+
+const (
+	DomainVerificationPending  DomainVerificationStatus = "pending"
+	DomainVerificationVerified DomainVerificationStatus = "verified"
+	DomainVerificationFailed   DomainVerificationStatus = "failed"
+	DomainVerificationExpired  DomainVerificationStatus = "expired"
+)
+
+// DomainVerification is a publisher's domain verification challenge and its state.
+//
+// For VerificationDNS, publish a TXT record named RecordName with value
+// RecordValue. For VerificationHTTP, serve Token as the body of WellKnownURL.
+type DomainVerification struct {
+	ID            string                   `json:"id"`
+	PublisherID   string                   `json:"publisher_id"`
+	Domain        string                   `json:"domain"`
+	Method        VerificationMethod       `json:"method"`
+	Status        DomainVerificationStatus `json:"status"`
+	Token         string                   `json:"token"`
+	RecordName    string                   `json:"record_name,omitempty"`
+	RecordValue   string                   `json:"record_value,omitempty"`
+	WellKnownURL  string                   `json:"well_known_url,omitempty"`
+	FailureReason string                   `json:"failure_reason,omitempty"`
+	LastCheckedAt *time.Time               `json:"last_checked_at"`
+	VerifiedAt    *time.Time               `json:"verified_at"`
+	ExpiresAt     *time.Time               `json:"expires_at"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// StartDomainVerificationRequest is the request body for starting domain verification.
+type StartDomainVerificationRequest struct {
+	Domain string             `json:"domain"`
+	Method VerificationMethod `json:"method"`
+}
+
+// StartDomainVerification issues a new challenge for proving control of a
+// domain. Any earlier challenge for the publisher is replaced.
+func (c *Client) StartDomainVerification(ctx context.Context, publisherSlug string, req *StartDomainVerificationRequest) (*DomainVerification, error) {
+	var v DomainVerification
+	path := fmt.Sprintf("/v1/publishers/%s/domain-verification", publisherSlug)
+	if err := c.post(ctx, path, req, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// GetDomainVerification returns the publisher's current domain verification.
+func (c *Client) GetDomainVerification(ctx context.Context, publisherSlug string) (*DomainVerification, error) {
+	var v DomainVerification
+	path := fmt.Sprintf("/v1/publishers/%s/domain-verification", publisherSlug)
+	if err := c.get(ctx, path, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// RecheckDomainVerification asks the registry to check the challenge again
+// and returns the updated verification.
+func (c *Client) RecheckDomainVerification(ctx context.Context, publisherSlug string) (*DomainVerification, error) {
+	var v DomainVerification
+	path := fmt.Sprintf("/v1/publishers/%s/domain-verification/check", publisherSlug)
+	if err := c.post(ctx, path, nil, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// WellKnownChallengeURL returns the URL at which the HTTP challenge for
+// domain is expected to be served.
+func WellKnownChallengeURL(domain string) string {
+	return "https://" + domain + WellKnownChallengePath
+}
+
+// CheckWellKnownChallenge fetches the HTTP challenge for v and confirms it
+// serves v.Token, so publishers can verify their setup before asking the
+// registry to re-check. It returns ErrChallengeMismatch if the file is
+// missing or has the wrong content, or if v has no token to compare against.
+func (c *Client) CheckWellKnownChallenge(ctx context.Context, v *DomainVerification) error {
+	if v.Token == "" {
+		// An empty token would match an empty file.
+		return fmt.Errorf("%w: verification has no token", ErrChallengeMismatch)
+	}
+	target := v.WellKnownURL
+	if target == "" {
+		target = WellKnownChallengeURL(v.Domain)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.httpClientFor(callOptionsFrom(ctx)).Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching challenge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned status %d", ErrChallengeMismatch, target, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxChallengeSize))
+	if err != nil {
+		return fmt.Errorf("reading challenge: %w", err)
+	}
+	if strings.TrimSpace(string(body)) != v.Token {
+		return fmt.Errorf("%w: %s does not contain the expected token", ErrChallengeMismatch, target)
+	}
+	return nil
+}
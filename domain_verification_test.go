@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func fakeDomainVerificationAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	status := "pending"
+
+	verification := func(body StartDomainVerificationRequest) map[string]interface{} {
+		return map[string]interface{}{
+			"id":             "dv-1",
+			"domain":         body.Domain,
+			"method":         body.Method,
+			"status":         status,
+			"token":          "ov-verify-123",
+			"record_name":    "_omniview-challenge." + body.Domain,
+			"record_value":   "omniview-verification=ov-verify-123",
+			"well_known_url": WellKnownChallengeURL(body.Domain),
+		}
+	}
+
+	mux.HandleFunc("/v1/publishers/acme/domain-verification", func(w http.ResponseWriter, r *http.Request) {
+		body := StartDomainVerificationRequest{Domain: "acme.example.com", Method: VerificationDNS}
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": verification(body)})
+	})
+
+	mux.HandleFunc("/v1/publishers/acme/domain-verification/check", func(w http.ResponseWriter, r *http.Request) {
+		status = "verified"
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    verification(StartDomainVerificationRequest{Domain: "acme.example.com", Method: VerificationDNS}),
+		})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestClient_DomainVerificationFlow(t *testing.T) {
+	srv := fakeDomainVerificationAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	v, err := c.StartDomainVerification(ctx, "acme", &StartDomainVerificationRequest{
+		Domain: "acme.example.com",
+		Method: VerificationDNS,
+	})
+	if err != nil {
+		t.Fatalf("StartDomainVerification() error: %v", err)
+	}
+	if v.Status != DomainVerificationPending {
+		t.Fatalf("expected pending, got %s", v.Status)
+	}
+	if v.RecordName != "_omniview-challenge.acme.example.com" || v.RecordValue == "" {
+		t.Fatalf("expected DNS challenge, got %+v", v)
+	}
+
+	got, err := c.GetDomainVerification(ctx, "acme")
+	if err != nil {
+		t.Fatalf("GetDomainVerification() error: %v", err)
+	}
+	if got.Token != v.Token {
+		t.Fatalf("expected token %s, got %s", v.Token, got.Token)
+	}
+
+	v, err = c.RecheckDomainVerification(ctx, "acme")
+	if err != nil {
+		t.Fatalf("RecheckDomainVerification() error: %v", err)
+	}
+	if v.Status != DomainVerificationVerified {
+		t.Fatalf("expected verified, got %s", v.Status)
+	}
+}
+
+func TestClient_CheckWellKnownChallenge(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/empty":
+			return
+		case WellKnownChallengePath:
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("ov-verify-123\n"))
+	}))
+	defer site.Close()
+
+	c := NewClient()
+	ctx := context.Background()
+
+	v := &DomainVerification{Method: VerificationHTTP, Token: "ov-verify-123", WellKnownURL: site.URL + WellKnownChallengePath}
+	if err := c.CheckWellKnownChallenge(ctx, v); err != nil {
+		t.Fatalf("CheckWellKnownChallenge() error: %v", err)
+	}
+
+	v.Token = "something-else"
+	if err := c.CheckWellKnownChallenge(ctx, v); !errors.Is(err, ErrChallengeMismatch) {
+		t.Fatalf("expected ErrChallengeMismatch for wrong token, got %v", err)
+	}
+
+	empty := &DomainVerification{Method: VerificationHTTP, WellKnownURL: site.URL + "/empty"}
+	if err := c.CheckWellKnownChallenge(ctx, empty); !errors.Is(err, ErrChallengeMismatch) {
+		t.Fatalf("expected ErrChallengeMismatch for empty token, got %v", err)
+	}
+
+	v.WellKnownURL = site.URL + "/missing"
+	if err := c.CheckWellKnownChallenge(ctx, v); !errors.Is(err, ErrChallengeMismatch) {
+		t.Fatalf("expected ErrChallengeMismatch for missing file, got %v", err)
+	}
+}
+
+func TestWellKnownChallengeURL(t *testing.T) {
+	got := WellKnownChallengeURL("acme.example.com")
+	if got != "https://acme.example.com/.well-known/omniview-verification.txt" {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+}
@@ -21,8 +21,11 @@ func (c *Client) GetDownloadURL(ctx context.Context, pluginID, version, arch str
 		return "", fmt.Errorf("creating request: %w", err)
 	}
 
+	co := callOptionsFrom(ctx)
+	co.applyHeaders(req)
+
 	// Don't follow redirects — we want the Location header
-	client := *c.httpClient
+	client := *c.httpClientFor(co)
 	client.CheckRedirect = func(*http.Request, []*http.Request) error {
 		return http.ErrUseLastResponse
 	}
@@ -85,6 +88,13 @@ func (c *Client) DownloadPlugin(ctx context.Context, pluginID, version string) (
 	if err != nil {
 		return "", fmt.Errorf("getting version info: %w", err)
 	}
+	pinned := c.isPinned(pluginID, version)
+	if v.Yanked && !pinned {
+		return "", &YankedVersionError{PluginID: pluginID, Version: version, Reason: v.YankReason}
+	}
+	if !v.Visible && !pinned {
+		return "", fmt.Errorf("%w: %s@%s", ErrVersionHidden, pluginID, version)
+	}
 
 	// 2. Determine current platform
 	platform := CurrentPlatform()
@@ -94,6 +104,9 @@ func (c *Client) DownloadPlugin(ctx context.Context, pluginID, version string) (
 	if !ok {
 		return "", fmt.Errorf("%w: %s", ErrNoPlatformArtifact, platform)
 	}
+	if want, ok := c.lockfile.pinnedChecksum(pluginID, version); ok && want != artifact.Checksum {
+		return "", fmt.Errorf("%w: %s@%s is pinned with checksum %s, registry has %s", ErrChecksumMismatch, pluginID, version, want, artifact.Checksum)
+	}
 
 	// 4. Get download URL (follows redirect)
 	downloadURL, err := c.GetDownloadURL(ctx, pluginID, version, platform)
@@ -115,7 +128,8 @@ func (c *Client) DownloadPlugin(ctx context.Context, pluginID, version string) (
 		return "", fmt.Errorf("creating download request: %w", err)
 	}
 
-	dlResp, err := c.httpClient.Do(dlReq)
+	// Call headers are not forwarded to the CDN; only the timeout applies.
+	dlResp, err := c.httpClientFor(callOptionsFrom(ctx)).Do(dlReq)
 	if err != nil {
 		tmpFile.Close()
 		os.Remove(tmpPath)
@@ -146,7 +160,7 @@ func (c *Client) DownloadPlugin(ctx context.Context, pluginID, version string) (
 	}
 
 	// 7. Verify signature
-	if err := VerifyArtifactSignature(checksum, artifact.Signature); err != nil {
+	if _, err := c.VerifyArtifact(checksum, &artifact); err != nil {
 		os.Remove(tmpPath)
 		return "", fmt.Errorf("signature verification failed: %w", err)
 	}
@@ -20,6 +20,60 @@ var (
 
 	// ErrEmptyVersion is returned when a version string is empty.
 	ErrEmptyVersion = errors.New("version string is empty")
+
+	// ErrCredentialsNotFound is returned when a credential store has no entry for a server.
+	ErrCredentialsNotFound = errors.New("credentials not found")
+
+	// ErrDecryptFailed is returned when encrypted data cannot be authenticated with the given key.
+	ErrDecryptFailed = errors.New("decryption failed: wrong key or corrupted data")
+
+	// ErrEmptyMasterKey is returned when encrypting or decrypting with an empty MasterKey.
+	ErrEmptyMasterKey = errors.New("master key is empty")
+
+	// ErrMalformedToken is returned when a JWT cannot be decoded.
+	ErrMalformedToken = errors.New("malformed token")
+
+	// ErrNoToken is returned when an operation needs a bearer token but none is configured.
+	ErrNoToken = errors.New("no token configured")
+
+	// ErrOAuthStateMismatch is returned when an OAuth redirect carries an unexpected state value.
+	ErrOAuthStateMismatch = errors.New("oauth state mismatch")
+
+	// ErrPermissionDenied is returned when the caller lacks a publisher permission.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrInvalidInput is returned when a request fails client-side validation.
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrChallengeMismatch is returned when a domain verification challenge is not served correctly.
+	ErrChallengeMismatch = errors.New("domain verification challenge mismatch")
+
+	// ErrVersionYanked is returned when a yanked version is requested without being pinned.
+	ErrVersionYanked = errors.New("version has been yanked")
+
+	// ErrVersionHidden is returned when downloading a hidden version that is not pinned.
+	ErrVersionHidden = errors.New("version is hidden")
+
+	// ErrNoEligibleVersion is returned when no version of a plugin can be resolved.
+	ErrNoEligibleVersion = errors.New("no eligible version")
+
+	// ErrUploadURLExpired is returned when a presigned upload URL has expired and could not be refreshed.
+	ErrUploadURLExpired = errors.New("presigned upload URL expired")
+
+	// ErrInvalidTransition is returned when a submission cannot move to the requested status.
+	ErrInvalidTransition = errors.New("invalid submission status transition")
+
+	// ErrNotReviewer is returned when a review queue operation is attempted without the required role.
+	ErrNotReviewer = errors.New("caller is not a reviewer")
+
+	// ErrUnknownSigningKey is returned when an artifact is signed by a key that is not in the trust store.
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+
+	// ErrSigningKeyRevoked is returned when an artifact is signed by a revoked key.
+	ErrSigningKeyRevoked = errors.New("signing key revoked")
+
+	// ErrSigningKeyExpired is returned when an artifact is signed by a key outside its validity window.
+	ErrSigningKeyExpired = errors.New("signing key not valid at this time")
 )
 
 // APIError represents an error response from the API.
@@ -41,11 +95,151 @@ func IsNotFound(err error) bool {
 	return false
 }
 
-// IsForbidden returns true if the error is a 403 API error.
+// IsForbidden returns true if the error is a 403 API error or a
+// client-side permission check failure.
 func IsForbidden(err error) bool {
+	if errors.Is(err, ErrPermissionDenied) {
+		return true
+	}
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
 		return apiErr.StatusCode == 403
 	}
 	return false
 }
+
+// PermissionError is returned when the caller lacks a publisher permission,
+// either because the caller's known access does not include it or because the
+// server rejected the call with 403. In the latter case Err holds the *APIError.
+type PermissionError struct {
+	Slug       string
+	PluginID   string
+	Role       string
+	Permission Permission
+	Err        error
+}
+
+func (e *PermissionError) Error() string {
+	subject := fmt.Sprintf("publisher %q", e.Slug)
+	if e.PluginID != "" {
+		subject = fmt.Sprintf("plugin %q", e.PluginID)
+	}
+	msg := fmt.Sprintf("%s: missing %s permission", subject, e.Permission)
+	if e.Role != "" {
+		msg = fmt.Sprintf("%s: role %q lacks %s permission", subject, e.Role, e.Permission)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *PermissionError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrPermissionDenied, e.Err}
+	}
+	return []error{ErrPermissionDenied}
+}
+
+// asPermissionError converts a 403 API error into a *PermissionError for the
+// given permission. Other errors are returned unchanged.
+func asPermissionError(err error, slug, pluginID string, p Permission) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 403 {
+		return &PermissionError{Slug: slug, PluginID: pluginID, Permission: p, Err: err}
+	}
+	return err
+}
+
+// FieldError describes a client-side validation failure for a single field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// YankedVersionError is returned when resolving or downloading a yanked
+// version that is not pinned in the client's lockfile.
+type YankedVersionError struct {
+	PluginID string
+	Version  string
+	Reason   string
+}
+
+func (e *YankedVersionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%s@%s has been yanked: %s", e.PluginID, e.Version, e.Reason)
+	}
+	return fmt.Sprintf("%s@%s has been yanked", e.PluginID, e.Version)
+}
+
+func (e *YankedVersionError) Unwrap() error {
+	return ErrVersionYanked
+}
+
+// TransitionError is returned when an action is not allowed for a submission
+// in its current status. From is empty if the status is not known, as when
+// the server rejected the action with 409; Err then holds the *APIError.
+type TransitionError struct {
+	SubmissionID string
+	From         SubmissionStatus
+	Action       string
+	Err          error
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == "" {
+		return fmt.Sprintf("cannot %s submission %s: %v", e.Action, e.SubmissionID, e.Err)
+	}
+	return fmt.Sprintf("cannot %s submission %s in status %q", e.Action, e.SubmissionID, e.From)
+}
+
+func (e *TransitionError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrInvalidTransition, e.Err}
+	}
+	return []error{ErrInvalidTransition}
+}
+
+// RoleError is returned when the caller lacks the reviewer role an operation
+// requires, either because the caller's token scopes do not grant it or
+// because the server rejected the call with 403. In the latter case Err holds
+// the *APIError.
+type RoleError struct {
+	Required RegistryRole
+	Action   string
+	Err      error
+}
+
+func (e *RoleError) Error() string {
+	msg := fmt.Sprintf("%s requires the %s role", e.Action, e.Required)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *RoleError) Unwrap() []error {
+	errs := []error{ErrNotReviewer, ErrPermissionDenied}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// asRoleError converts a 403 API error into a *RoleError. Other errors are
+// returned unchanged.
+func asRoleError(err error, role RegistryRole, action string) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 403 {
+		return &RoleError{Required: role, Action: action, Err: err}
+	}
+	return err
+}
@@ -1,3 +1,8 @@
 module github.com/omniviewdev/registry
 
 go 1.23.0
+
+require (
+	golang.org/x/crypto v0.36.0
+	gopkg.in/yaml.v3 v3.0.1
+)
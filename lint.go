@@ -0,0 +1,263 @@
+package registry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultMaxArtifactFileSize is the largest single file LintArchive
+	// accepts when LintOptions.MaxFileSize is zero.
+	DefaultMaxArtifactFileSize = 100 << 20
+
+	// maxManifestSize bounds how much of plugin.yaml is read.
+	maxManifestSize = 1 << 20
+)
+
+// DefaultForbiddenPatterns are path.Match patterns for files that must not be
+// shipped in a plugin archive. They are matched against each element of an
+// entry's path.
+var DefaultForbiddenPatterns = []string{
+	".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx", "id_rsa", "id_ed25519",
+	".git", ".svn", ".hg", ".DS_Store",
+}
+
+// Lint rule IDs reported by LintArchive.
+const (
+	RuleArchiveUnreadable   = "archive.unreadable"
+	RuleManifestMissing     = "manifest.missing"
+	RuleManifestInvalid     = "manifest.invalid"
+	RuleManifestIDMismatch  = "manifest.id-mismatch"
+	RuleManifestVersion     = "manifest.version-mismatch"
+	RuleBinaryMissing       = "binary.missing"
+	RuleBinaryNotExecutable = "binary.not-executable"
+	RulePathUnsafe          = "path.unsafe"
+	RulePathDuplicate       = "path.duplicate"
+	RuleFileForbidden       = "file.forbidden-type"
+	RuleFileTooLarge        = "file.too-large"
+)
+
+// LintOptions configures LintArchive.
+type LintOptions struct {
+	// Platform is the platform the archive is built for.
+	Platform string
+	// PluginID and Version, if set, must match the manifest.
+	PluginID string
+	Version  string
+	// MaxFileSize is the largest allowed file. Defaults to
+	// DefaultMaxArtifactFileSize.
+	MaxFileSize int64
+	// Forbidden replaces DefaultForbiddenPatterns if non-nil.
+	Forbidden []string
+}
+
+// LintArchive inspects a plugin .tar.gz read from r without extracting it and
+// reports layout problems the registry would reject. Findings are reported
+// under opts.Platform in the same form as a submission's validation result;
+// a corrupt archive is reported as a finding rather than an error.
+func LintArchive(r io.Reader, opts LintOptions) (*ValidationReport, error) {
+	if !slices.Contains(SupportedPlatforms, opts.Platform) {
+		return nil, &FieldError{Field: "platform", Message: fmt.Sprintf("%q is not a supported platform", opts.Platform)}
+	}
+	l := &archiveLinter{opts: opts, seen: map[string]bool{}}
+	if l.opts.MaxFileSize <= 0 {
+		l.opts.MaxFileSize = DefaultMaxArtifactFileSize
+	}
+	if l.opts.Forbidden == nil {
+		l.opts.Forbidden = DefaultForbiddenPatterns
+	}
+
+	if err := l.scan(r); err != nil {
+		l.add(SeverityError, RuleArchiveUnreadable, err.Error(), "")
+	} else {
+		l.finish()
+	}
+
+	report := &ValidationReport{Architectures: map[string][]ValidationCheck{opts.Platform: l.checks}}
+	report.Passed = !report.HasErrors()
+	return report, nil
+}
+
+// LintArchiveFile lints the archive at path.
+func LintArchiveFile(path string, opts LintOptions) (*ValidationReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close()
+	return LintArchive(f, opts)
+}
+
+type archiveLinter struct {
+	opts   LintOptions
+	checks []ValidationCheck
+	seen   map[string]bool
+
+	manifest    []byte
+	hasManifest bool
+	binaries    []*tar.Header
+}
+
+func (l *archiveLinter) add(sev Severity, rule, msg, file string) {
+	l.checks = append(l.checks, ValidationCheck{
+		RuleID:       rule,
+		Severity:     sev,
+		Message:      msg,
+		File:         file,
+		Architecture: l.opts.Platform,
+	})
+}
+
+func (l *archiveLinter) scan(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading archive: %w", err)
+		}
+		if err := l.entry(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *archiveLinter) entry(hdr *tar.Header, r io.Reader) error {
+	name := strings.TrimSuffix(strings.TrimPrefix(hdr.Name, "./"), "/")
+	if !safeArchivePath(hdr.Name) {
+		l.add(SeverityError, RulePathUnsafe, "entry path is absolute or escapes the archive root", hdr.Name)
+		return nil
+	}
+	if l.seen[name] {
+		l.add(SeverityError, RulePathDuplicate, "entry appears more than once", name)
+	}
+	l.seen[name] = true
+
+	if p, ok := l.forbidden(name); ok {
+		l.add(SeverityError, RuleFileForbidden, fmt.Sprintf("files matching %q must not be packaged", p), name)
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return nil
+	case tar.TypeSymlink:
+		if path.IsAbs(hdr.Linkname) || !fs.ValidPath(path.Join(path.Dir(name), hdr.Linkname)) {
+			l.add(SeverityError, RulePathUnsafe, fmt.Sprintf("symlink target %q escapes the archive root", hdr.Linkname), name)
+		}
+		return nil
+	case tar.TypeReg:
+	default:
+		l.add(SeverityError, RuleFileForbidden, "only regular files, directories and symlinks are allowed", name)
+		return nil
+	}
+
+	if hdr.Size > l.opts.MaxFileSize {
+		l.add(SeverityError, RuleFileTooLarge, fmt.Sprintf("file is %d bytes, limit is %d", hdr.Size, l.opts.MaxFileSize), name)
+	}
+	if name == ManifestFile {
+		data, err := io.ReadAll(io.LimitReader(r, maxManifestSize+1))
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		l.manifest, l.hasManifest = data, true
+	}
+	if l.isTargetBinary(name) {
+		l.binaries = append(l.binaries, hdr)
+	}
+	return nil
+}
+
+// forbidden returns the forbidden pattern matching any element of name.
+func (l *archiveLinter) forbidden(name string) (string, bool) {
+	for _, elem := range strings.Split(name, "/") {
+		for _, p := range l.opts.Forbidden {
+			if ok, _ := path.Match(p, elem); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// isTargetBinary reports whether name is a binary for the target platform,
+// either under bin/<platform>/ or directly under bin/.
+func (l *archiveLinter) isTargetBinary(name string) bool {
+	rest, ok := strings.CutPrefix(name, "bin/")
+	if !ok {
+		return false
+	}
+	if sub, ok := strings.CutPrefix(rest, l.opts.Platform+"/"); ok {
+		return sub != ""
+	}
+	return !strings.Contains(rest, "/")
+}
+
+// finish runs the checks that need the whole archive.
+func (l *archiveLinter) finish() {
+	l.checkManifest()
+
+	if len(l.binaries) == 0 {
+		l.add(SeverityError, RuleBinaryMissing, fmt.Sprintf("no binary for %s under bin/ or bin/%s/", l.opts.Platform, l.opts.Platform), "")
+		return
+	}
+	if strings.HasPrefix(l.opts.Platform, "windows_") {
+		return
+	}
+	for _, b := range l.binaries {
+		if b.Mode&0o111 == 0 {
+			l.add(SeverityWarning, RuleBinaryNotExecutable, "binary is not executable", strings.TrimPrefix(b.Name, "./"))
+		}
+	}
+}
+
+func (l *archiveLinter) checkManifest() {
+	if !l.hasManifest {
+		l.add(SeverityError, RuleManifestMissing, "archive has no "+ManifestFile+" at its root", "")
+		return
+	}
+	if len(l.manifest) > maxManifestSize {
+		l.add(SeverityError, RuleManifestInvalid, "manifest is too large", ManifestFile)
+		return
+	}
+	var meta PluginMeta
+	if err := yaml.Unmarshal(l.manifest, &meta); err != nil {
+		l.add(SeverityError, RuleManifestInvalid, err.Error(), ManifestFile)
+		return
+	}
+	if err := validateManifest(&meta); err != nil {
+		l.add(SeverityError, RuleManifestInvalid, err.Error(), ManifestFile)
+	}
+	if l.opts.PluginID != "" && meta.ID != l.opts.PluginID {
+		l.add(SeverityError, RuleManifestIDMismatch, fmt.Sprintf("manifest id %q does not match %q", meta.ID, l.opts.PluginID), ManifestFile)
+	}
+	if l.opts.Version != "" && meta.Version != l.opts.Version {
+		l.add(SeverityError, RuleManifestVersion, fmt.Sprintf("manifest version %q does not match %q", meta.Version, l.opts.Version), ManifestFile)
+	}
+}
+
+// safeArchivePath reports whether an entry name stays inside the archive root.
+func safeArchivePath(name string) bool {
+	if name == "" || strings.Contains(name, `\`) || path.IsAbs(name) {
+		return false
+	}
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "./"), "/")
+	return fs.ValidPath(name) && name != "."
+}
@@ -0,0 +1,136 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildArchive writes headers and contents into an in-memory .tar.gz without
+// any of the packager's normalization, so tests can craft bad archives.
+func buildArchive(t *testing.T, entries []tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := e.hdr
+		if hdr == nil {
+			hdr = &tar.Header{Name: e.name, Mode: e.mode, Typeflag: tar.TypeReg}
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(e.content))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func ruleIDs(r *ValidationReport) []string {
+	var ids []string
+	for _, c := range r.All() {
+		ids = append(ids, c.RuleID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestLintArchive_packagedPlugin(t *testing.T) {
+	dir := writePluginDir(t, 0o644, time.Now())
+	var buf bytes.Buffer
+	if _, err := PackagePlugin(dir, &buf, PackageOptions{Platform: "linux_amd64"}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LintArchive(&buf, LintOptions{Platform: "linux_amd64", PluginID: "test-plugin", Version: "1.2.0"})
+	if err != nil {
+		t.Fatalf("LintArchive() error: %v", err)
+	}
+	if !r.Passed || len(r.All()) != 0 {
+		t.Fatalf("expected clean report, got:\n%s", r)
+	}
+}
+
+func TestLintArchive_findings(t *testing.T) {
+	data := buildArchive(t, []tarEntry{
+		{name: "plugin.yaml", mode: 0o644, content: "id: other-plugin\nversion: 1.0.0\n"},
+		{name: "bin/linux_amd64/plugin", mode: 0o644, content: "binary"},
+		{name: "../evil.sh", mode: 0o755, content: "rm -rf /"},
+		{name: "config/.env", mode: 0o644, content: "SECRET=1"},
+		{name: ".git/HEAD", mode: 0o644, content: "ref"},
+		{name: "assets/big.bin", mode: 0o644, content: strings.Repeat("x", 64)},
+		{name: "assets/big.bin", mode: 0o644, content: "again"},
+		{hdr: &tar.Header{Name: "assets/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"}},
+		{hdr: &tar.Header{Name: "dev/null", Typeflag: tar.TypeChar}},
+	})
+
+	r, err := LintArchive(bytes.NewReader(data), LintOptions{
+		Platform:    "linux_amd64",
+		PluginID:    "test-plugin",
+		Version:     "1.2.0",
+		MaxFileSize: 32,
+	})
+	if err != nil {
+		t.Fatalf("LintArchive() error: %v", err)
+	}
+	if r.Passed {
+		t.Fatal("expected failing report")
+	}
+
+	want := []string{
+		RuleBinaryNotExecutable,
+		RuleFileForbidden, RuleFileForbidden, RuleFileForbidden,
+		RuleFileTooLarge,
+		RuleManifestIDMismatch, RuleManifestVersion,
+		RulePathDuplicate,
+		RulePathUnsafe, RulePathUnsafe,
+	}
+	sort.Strings(want)
+	if got := ruleIDs(r); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected findings:\n got %v\nwant %v\n%s", got, want, r)
+	}
+	for _, c := range r.All() {
+		if c.Architecture != "linux_amd64" {
+			t.Fatalf("finding missing architecture: %+v", c)
+		}
+	}
+}
+
+func TestLintArchive_missingManifestAndBinary(t *testing.T) {
+	data := buildArchive(t, []tarEntry{
+		{name: "bin/darwin_arm64/plugin", mode: 0o755, content: "binary"},
+	})
+	r, err := LintArchive(bytes.NewReader(data), LintOptions{Platform: "linux_amd64"})
+	if err != nil {
+		t.Fatalf("LintArchive() error: %v", err)
+	}
+	want := []string{RuleBinaryMissing, RuleManifestMissing}
+	if got := ruleIDs(r); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected findings: %v", got)
+	}
+}
+
+func TestLintArchive_corrupt(t *testing.T) {
+	r, err := LintArchive(strings.NewReader("not an archive"), LintOptions{Platform: "linux_amd64"})
+	if err != nil {
+		t.Fatalf("LintArchive() error: %v", err)
+	}
+	if got := ruleIDs(r); len(got) != 1 || got[0] != RuleArchiveUnreadable {
+		t.Fatalf("unexpected findings: %v", got)
+	}
+}
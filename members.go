@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PublisherRole is a member's role within a publisher.
+type PublisherRole string
+
+const (
+	RoleOwner     PublisherRole = "owner"
+	RoleAdmin     PublisherRole = "admin"
+	RolePublisher PublisherRole = "publisher"
+	RoleViewer    PublisherRole = "viewer"
+)
+
+// Permissions returns the permissions granted by the role.
+func (r PublisherRole) Permissions() []Permission {
+	switch r {
+	case RoleOwner, RoleAdmin:
+		return []Permission{PermissionPublish, PermissionManagePlugins, PermissionManageMembers}
+	case RolePublisher:
+		return []Permission{PermissionPublish, PermissionManagePlugins}
+	}
+	return nil
+}
+
+// Has reports whether the role grants permission p.
+func (r PublisherRole) Has(p Permission) bool {
+	for _, rp := range r.Permissions() {
+		if rp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// PublisherMember is a user belonging to a publisher.
+type PublisherMember struct {
+	UserID    uint          `json:"user_id"`
+	Username  string        `json:"username"`
+	Email     string        `json:"email"`
+	Avatar    string        `json:"avatar"`
+	Role      PublisherRole `json:"role"`
+	InvitedBy *uint         `json:"invited_by"`
+	JoinedAt  time.Time     `json:"joined_at"`
+}
+
+// PublisherInvitation is a pending invitation to join a publisher.
+type PublisherInvitation struct {
+	ID            string        `json:"id"`
+	PublisherID   string        `json:"publisher_id"`
+	PublisherSlug string        `json:"publisher_slug"`
+	PublisherName string        `json:"publisher_name"`
+	Email         string        `json:"email"`
+	Role          PublisherRole `json:"role"`
+	InvitedByID   uint          `json:"invited_by_id"`
+	Status        string        `json:"status"`
+	ExpiresAt     *time.Time    `json:"expires_at"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// InviteMemberRequest is the request body for inviting a user to a publisher.
+type InviteMemberRequest struct {
+	Email string        `json:"email"`
+	Role  PublisherRole `json:"role"`
+}
+
+// UpdateMemberRoleRequest is the request body for changing a member's role.
+type UpdateMemberRoleRequest struct {
+	Role PublisherRole `json:"role"`
+}
+
+// ListMembers returns the members of a publisher.
+func (c *Client) ListMembers(ctx context.Context, publisherSlug string) ([]PublisherMember, error) {
+	var members []PublisherMember
+	path := fmt.Sprintf("/v1/publishers/%s/members", publisherSlug)
+	if err := c.get(ctx, path, &members); err != nil {
+		return nil, err
+	}
+	if members == nil {
+		members = []PublisherMember{}
+	}
+	return members, nil
+}
+
+// InviteMember invites a user by email to join a publisher with the given role.
+// Requires the manage_members permission.
+func (c *Client) InviteMember(ctx context.Context, publisherSlug string, req *InviteMemberRequest) (*PublisherInvitation, error) {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+	var inv PublisherInvitation
+	path := fmt.Sprintf("/v1/publishers/%s/members/invitations", publisherSlug)
+	if err := c.post(ctx, path, req, &inv); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	return &inv, nil
+}
+
+// UpdateMemberRole changes a member's role. Requires the manage_members permission.
+func (c *Client) UpdateMemberRole(ctx context.Context, publisherSlug string, userID uint, role PublisherRole) (*PublisherMember, error) {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+	var m PublisherMember
+	path := fmt.Sprintf("/v1/publishers/%s/members/%d", publisherSlug, userID)
+	if err := c.patch(ctx, path, &UpdateMemberRoleRequest{Role: role}, &m); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	// The caller may have changed their own role.
+	c.forgetAccess(publisherSlug)
+	return &m, nil
+}
+
+// RemoveMember removes a member from a publisher. Requires the manage_members permission.
+func (c *Client) RemoveMember(ctx context.Context, publisherSlug string, userID uint) error {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManageMembers); err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/v1/publishers/%s/members/%d", publisherSlug, userID)
+	if err := c.del(ctx, path, nil); err != nil {
+		return asPermissionError(err, publisherSlug, "", PermissionManageMembers)
+	}
+	c.forgetAccess(publisherSlug)
+	return nil
+}
+
+// ListInvitations returns the pending invitations addressed to the caller.
+func (c *Client) ListInvitations(ctx context.Context) ([]PublisherInvitation, error) {
+	var invs []PublisherInvitation
+	if err := c.get(ctx, "/v1/invitations", &invs); err != nil {
+		return nil, err
+	}
+	if invs == nil {
+		invs = []PublisherInvitation{}
+	}
+	return invs, nil
+}
+
+// AcceptInvitation accepts an invitation and returns the resulting membership.
+func (c *Client) AcceptInvitation(ctx context.Context, invitationID string) (*PublisherMember, error) {
+	var m PublisherMember
+	path := fmt.Sprintf("/v1/invitations/%s/accept", invitationID)
+	if err := c.post(ctx, path, nil, &m); err != nil {
+		return nil, err
+	}
+	c.forgetAccess()
+	return &m, nil
+}
+
+// DeclineInvitation declines an invitation.
+func (c *Client) DeclineInvitation(ctx context.Context, invitationID string) error {
+	path := fmt.Sprintf("/v1/invitations/%s/decline", invitationID)
+	return c.post(ctx, path, nil, nil)
+}
@@ -0,0 +1,281 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func fakeMembersAPI(t *testing.T, role string, canManage bool) (*httptest.Server, *int32) {
+	t.Helper()
+	var mutations int32
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/publishers/omniview/can-i", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"publisher_id":       "pub-1",
+				"slug":               "omniview",
+				"member":             true,
+				"role":               role,
+				"can_publish":        true,
+				"can_manage_plugins": true,
+				"can_manage_members": canManage,
+			},
+		})
+	})
+
+	mux.HandleFunc("/v1/publishers/omniview/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data": []map[string]interface{}{
+				{"user_id": 1, "username": "alice", "role": "owner"},
+				{"user_id": 2, "username": "bob", "role": "publisher"},
+			},
+		})
+	})
+
+	mux.HandleFunc("/v1/publishers/omniview/members/invitations", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&mutations, 1)
+		var body InviteMemberRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": "inv-1", "email": body.Email, "role": body.Role, "status": "pending"},
+		})
+	})
+
+	mux.HandleFunc("/v1/publishers/omniview/members/2", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&mutations, 1)
+		switch r.Method {
+		case http.MethodPatch:
+			var body UpdateMemberRoleRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"user_id": 2, "username": "bob", "role": body.Role},
+			})
+		case http.MethodDelete:
+			writeJSON(w, map[string]interface{}{"success": true})
+		}
+	})
+
+	mux.HandleFunc("/v1/invitations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    []map[string]interface{}{{"id": "inv-1", "publisher_slug": "omniview", "role": "viewer"}},
+		})
+	})
+
+	mux.HandleFunc("/v1/invitations/inv-1/accept", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"user_id": 3, "role": "viewer"},
+		})
+	})
+
+	mux.HandleFunc("/v1/invitations/inv-1/decline", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"success": true})
+	})
+
+	return httptest.NewServer(mux), &mutations
+}
+
+func TestClient_ListMembers(t *testing.T) {
+	srv, _ := fakeMembersAPI(t, "owner", true)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	members, err := c.ListMembers(context.Background(), "omniview")
+	if err != nil {
+		t.Fatalf("ListMembers() error: %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(members))
+	}
+	if members[1].Role != RolePublisher {
+		t.Fatalf("expected publisher role, got %s", members[1].Role)
+	}
+}
+
+func TestClient_memberManagement(t *testing.T) {
+	srv, mutations := fakeMembersAPI(t, "owner", true)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+	if _, err := c.CheckPublisherAccess(ctx, "omniview"); err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err := c.InviteMember(ctx, "omniview", &InviteMemberRequest{Email: "carol@example.com", Role: RoleViewer})
+	if err != nil {
+		t.Fatalf("InviteMember() error: %v", err)
+	}
+	if inv.Email != "carol@example.com" || inv.Role != RoleViewer {
+		t.Fatalf("unexpected invitation: %+v", inv)
+	}
+
+	m, err := c.UpdateMemberRole(ctx, "omniview", 2, RoleAdmin)
+	if err != nil {
+		t.Fatalf("UpdateMemberRole() error: %v", err)
+	}
+	if m.Role != RoleAdmin {
+		t.Fatalf("expected admin, got %s", m.Role)
+	}
+
+	if err := c.RemoveMember(ctx, "omniview", 2); err != nil {
+		t.Fatalf("RemoveMember() error: %v", err)
+	}
+	if atomic.LoadInt32(mutations) != 3 {
+		t.Fatalf("expected 3 mutations, got %d", *mutations)
+	}
+}
+
+func TestClient_memberManagement_deniedLocally(t *testing.T) {
+	srv, mutations := fakeMembersAPI(t, "publisher", false)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+	if _, err := c.CheckPublisherAccess(ctx, "omniview"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c.InviteMember(ctx, "omniview", &InviteMemberRequest{Email: "carol@example.com", Role: RoleViewer})
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("expected PermissionError, got %v", err)
+	}
+	if permErr.Permission != PermissionManageMembers || permErr.Role != "publisher" {
+		t.Fatalf("unexpected PermissionError: %+v", permErr)
+	}
+	if !IsForbidden(err) {
+		t.Fatal("expected IsForbidden to recognise PermissionError")
+	}
+	if err := c.RemoveMember(ctx, "omniview", 2); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+	if atomic.LoadInt32(mutations) != 0 {
+		t.Fatalf("expected no requests to reach the server, got %d", *mutations)
+	}
+
+	// Changing credentials discards the cached access.
+	c.SetToken("other-user")
+	if _, err := c.InviteMember(ctx, "omniview", &InviteMemberRequest{Email: "carol@example.com"}); err != nil {
+		t.Fatalf("expected call to reach server after credential change, got %v", err)
+	}
+}
+
+func TestClient_memberManagement_perCallCredentials(t *testing.T) {
+	srv, mutations := fakeMembersAPI(t, "viewer", false)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	tenantA := WithCallOptions(context.Background(), CallToken("tenant-a"))
+	tenantB := WithCallOptions(context.Background(), CallToken("tenant-b"))
+
+	// Access checked for one tenant must not deny another tenant's call.
+	if _, err := c.CheckPublisherAccess(tenantA, "omniview"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.InviteMember(tenantB, "omniview", &InviteMemberRequest{Email: "carol@example.com", Role: RoleViewer}); err != nil {
+		t.Fatalf("expected tenant B's call to reach the server, got %v", err)
+	}
+
+	// Nor does access cached for the client's own credentials apply to them.
+	if _, err := c.CheckPublisherAccess(context.Background(), "omniview"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.InviteMember(tenantB, "omniview", &InviteMemberRequest{Email: "carol@example.com", Role: RoleViewer}); err != nil {
+		t.Fatalf("expected per-call credentials to bypass cached access, got %v", err)
+	}
+	if atomic.LoadInt32(mutations) != 2 {
+		t.Fatalf("expected 2 requests to reach the server, got %d", *mutations)
+	}
+}
+
+func TestClient_CheckPublisherAccess_cachedUnderRequestedSlug(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server leaves out the slug.
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"publisher_id": "pub-1", "member": true, "role": "viewer"},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+	if _, err := c.CheckPublisherAccess(ctx, "omniview"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveMember(ctx, "omniview", 2); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expected cached access to deny locally, got %v", err)
+	}
+}
+
+func TestClient_invitations(t *testing.T) {
+	srv, _ := fakeMembersAPI(t, "owner", true)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	invs, err := c.ListInvitations(ctx)
+	if err != nil {
+		t.Fatalf("ListInvitations() error: %v", err)
+	}
+	if len(invs) != 1 || invs[0].PublisherSlug != "omniview" {
+		t.Fatalf("unexpected invitations: %+v", invs)
+	}
+	m, err := c.AcceptInvitation(ctx, "inv-1")
+	if err != nil {
+		t.Fatalf("AcceptInvitation() error: %v", err)
+	}
+	if m.Role != RoleViewer {
+		t.Fatalf("expected viewer, got %s", m.Role)
+	}
+	if err := c.DeclineInvitation(ctx, "inv-1"); err != nil {
+		t.Fatalf("DeclineInvitation() error: %v", err)
+	}
+}
+
+func TestPublisherRole_Has(t *testing.T) {
+	if !RoleOwner.Has(PermissionManageMembers) {
+		t.Fatal("expected owner to manage members")
+	}
+	if RolePublisher.Has(PermissionManageMembers) || !RolePublisher.Has(PermissionPublish) {
+		t.Fatal("unexpected publisher permissions")
+	}
+	if RoleViewer.Has(PermissionPublish) {
+		t.Fatal("expected viewer to have no permissions")
+	}
+}
+
+func TestClient_memberManagement_serverDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "forbidden"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("tok"))
+	ctx := context.Background()
+
+	checks := map[string]error{}
+	_, checks["invite"] = c.InviteMember(ctx, "omniview", &InviteMemberRequest{Email: "a@b.c", Role: RoleViewer})
+	_, checks["update"] = c.UpdateMemberRole(ctx, "omniview", 2, RoleViewer)
+	checks["remove"] = c.RemoveMember(ctx, "omniview", 2)
+	for name, err := range checks {
+		var permErr *PermissionError
+		if !errors.As(err, &permErr) || permErr.Permission != PermissionManageMembers {
+			t.Errorf("%s: expected PermissionError for manage_members, got %v", name, err)
+		}
+	}
+}
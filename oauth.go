@@ -0,0 +1,258 @@
+package registry
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOAuthClientID     = "omniview-cli"
+	defaultOAuthCallbackPath = "/callback"
+)
+
+// BrowserLoginOptions configures the authorization-code-with-PKCE login flow.
+type BrowserLoginOptions struct {
+	// OpenURL is called with the authorize URL and should open it in the
+	// user's browser. It is required.
+	OpenURL func(authorizeURL string) error
+
+	// ClientID identifies the application to the authorization server.
+	// Defaults to "omniview-cli".
+	ClientID string
+
+	// Scopes requested for the token.
+	Scopes []string
+
+	// CallbackPath is the loopback redirect path. Defaults to "/callback".
+	CallbackPath string
+
+	// Store, if set, receives the issued token keyed by the client's base URL.
+	Store CredentialStore
+}
+
+// OAuthTokenResponse is returned on a successful authorization code exchange.
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// OAuthError represents an RFC 6749 error response, from either the redirect
+// or the token endpoint.
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth error: %s — %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("oauth error: %s", e.Code)
+}
+
+// callbackResult carries the outcome of the loopback redirect.
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// BrowserLogin signs the user in through the browser using the OAuth 2.0
+// authorization code flow with PKCE (RFC 7636). It listens on a random
+// 127.0.0.1 port for the redirect, validates the returned state and exchanges
+// the code for a token. The flow ends when the redirect arrives or ctx is done.
+func (c *Client) BrowserLogin(ctx context.Context, opts *BrowserLoginOptions) (*OAuthTokenResponse, error) {
+	if opts == nil || opts.OpenURL == nil {
+		return nil, errors.New("browser login: OpenURL is required")
+	}
+	clientID := opts.ClientID
+	if clientID == "" {
+		clientID = defaultOAuthClientID
+	}
+	callbackPath := opts.CallbackPath
+	if callbackPath == "" {
+		callbackPath = defaultOAuthCallbackPath
+	}
+
+	verifier, err := randomURLToken(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomURLToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("starting loopback listener: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", ln.Addr().String(), callbackPath)
+
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		res := parseCallback(r.URL.Query(), state)
+		select {
+		case results <- res:
+		default:
+			// A result was already delivered; ignore repeated redirects.
+		}
+		if res.err != nil {
+			http.Error(w, "Sign-in failed. You can close this window.", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, "Signed in. You can close this window.")
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Close()
+
+	authorizeURL := c.AuthorizeURL(clientID, redirectURI, state, pkceChallenge(verifier), opts.Scopes)
+	if err := opts.OpenURL(authorizeURL); err != nil {
+		return nil, fmt.Errorf("opening browser: %w", err)
+	}
+
+	var res callbackResult
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	tok, err := c.ExchangeAuthorizationCode(ctx, clientID, res.code, redirectURI, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Store != nil {
+		if err := opts.Store.Store(&Credentials{ServerURL: c.baseURL, Token: tok.AccessToken}); err != nil {
+			return nil, fmt.Errorf("storing credentials: %w", err)
+		}
+	}
+	return tok, nil
+}
+
+// AuthorizeURL builds the authorization endpoint URL for a PKCE (S256) request.
+func (c *Client) AuthorizeURL(clientID, redirectURI, state, codeChallenge string, scopes []string) string {
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", clientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("state", state)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	if len(scopes) > 0 {
+		q.Set("scope", strings.Join(scopes, " "))
+	}
+	return c.baseURL + "/v1/auth/oauth/authorize?" + q.Encode()
+}
+
+// ExchangeAuthorizationCode exchanges an authorization code and its PKCE
+// verifier for a token.
+// Returns (nil, *OAuthError) for RFC 6749 errors such as invalid_grant.
+func (c *Client) ExchangeAuthorizationCode(ctx context.Context, clientID, code, redirectURI, codeVerifier string) (*OAuthTokenResponse, error) {
+	body := map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     clientID,
+		"code":          code,
+		"redirect_uri":  redirectURI,
+		"code_verifier": codeVerifier,
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/auth/oauth/token", bytesReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	co := callOptionsFrom(ctx)
+	co.applyHeaders(req)
+
+	resp, err := c.httpClientFor(co).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	// RFC 6749 errors come as 400 with error/error_description fields
+	if resp.StatusCode >= 400 {
+		var oauthErr OAuthError
+		if json.Unmarshal(respBody, &oauthErr) == nil && oauthErr.Code != "" {
+			return nil, &oauthErr
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
+	}
+
+	var envelope apiResponse
+	if err := json.Unmarshal(respBody, &envelope); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if !envelope.Success {
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: envelope.Message}
+	}
+
+	var tokenResp OAuthTokenResponse
+	if envelope.Data != nil {
+		if err := json.Unmarshal(envelope.Data, &tokenResp); err != nil {
+			return nil, fmt.Errorf("decoding token response: %w", err)
+		}
+	}
+	return &tokenResp, nil
+}
+
+// parseCallback validates the redirect query against the expected state.
+func parseCallback(q url.Values, wantState string) callbackResult {
+	if q.Get("state") != wantState {
+		return callbackResult{err: ErrOAuthStateMismatch}
+	}
+	if code := q.Get("error"); code != "" {
+		return callbackResult{err: &OAuthError{Code: code, Description: q.Get("error_description")}}
+	}
+	code := q.Get("code")
+	if code == "" {
+		return callbackResult{err: &OAuthError{Code: "invalid_request", Description: "redirect is missing the authorization code"}}
+	}
+	return callbackResult{code: code}
+}
+
+// pkceChallenge returns the S256 code challenge for verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomURLToken returns n random bytes encoded as unpadded base64url.
+func randomURLToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAuthServer is a stand-in OAuth authorization server. Its authorize
+// endpoint immediately redirects back with a code, as if the user approved.
+type fakeAuthServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	challenge string
+	// stateOverride, if set, replaces the state echoed back on redirect.
+	stateOverride string
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+	fs := &fakeAuthServer{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/auth/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code_challenge_method") != "S256" || q.Get("response_type") != "code" {
+			http.Error(w, "bad authorize request", http.StatusBadRequest)
+			return
+		}
+		fs.mu.Lock()
+		fs.challenge = q.Get("code_challenge")
+		state := q.Get("state")
+		if fs.stateOverride != "" {
+			state = fs.stateOverride
+		}
+		fs.mu.Unlock()
+
+		redirect, _ := url.Parse(q.Get("redirect_uri"))
+		rq := redirect.Query()
+		rq.Set("code", "auth-code-1")
+		rq.Set("state", state)
+		redirect.RawQuery = rq.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+
+	mux.HandleFunc("/v1/auth/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		fs.mu.Lock()
+		challenge := fs.challenge
+		fs.mu.Unlock()
+
+		if body["code"] != "auth-code-1" || pkceChallenge(body["code_verifier"]) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]interface{}{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"access_token": "browser-jwt",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			},
+		})
+	})
+
+	fs.Server = httptest.NewServer(mux)
+	return fs
+}
+
+// followInBackground simulates the browser by following the authorize URL.
+func followInBackground(authorizeURL string) error {
+	go func() {
+		resp, err := http.Get(authorizeURL)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	return nil
+}
+
+func TestBrowserLogin(t *testing.T) {
+	fs := newFakeAuthServer(t)
+	defer fs.Close()
+
+	store := NewFileStore(filepath.Join(t.TempDir(), "creds.json"))
+	c := NewClient(WithBaseURL(fs.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tok, err := c.BrowserLogin(ctx, &BrowserLoginOptions{
+		OpenURL: followInBackground,
+		Scopes:  []string{"plugins:read"},
+		Store:   store,
+	})
+	if err != nil {
+		t.Fatalf("BrowserLogin() error: %v", err)
+	}
+	if tok.AccessToken != "browser-jwt" {
+		t.Fatalf("expected browser-jwt, got %s", tok.AccessToken)
+	}
+
+	creds, err := store.Get(fs.URL)
+	if err != nil {
+		t.Fatalf("expected token to be stored: %v", err)
+	}
+	if creds.Token != "browser-jwt" {
+		t.Fatalf("expected stored browser-jwt, got %s", creds.Token)
+	}
+}
+
+func TestBrowserLogin_stateMismatch(t *testing.T) {
+	fs := newFakeAuthServer(t)
+	defer fs.Close()
+	fs.stateOverride = "forged"
+
+	c := NewClient(WithBaseURL(fs.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := c.BrowserLogin(ctx, &BrowserLoginOptions{OpenURL: followInBackground})
+	if !errors.Is(err, ErrOAuthStateMismatch) {
+		t.Fatalf("expected ErrOAuthStateMismatch, got %v", err)
+	}
+}
+
+func TestBrowserLogin_contextCanceled(t *testing.T) {
+	c := NewClient(WithBaseURL("http://127.0.0.1:1"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.BrowserLogin(ctx, &BrowserLoginOptions{OpenURL: func(string) error { return nil }})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestAuthorizeURL(t *testing.T) {
+	c := NewClient(WithBaseURL("https://example.com"))
+	u := c.AuthorizeURL("cli", "http://127.0.0.1:5000/callback", "st", "ch", []string{"a", "b"})
+	if !strings.HasPrefix(u, "https://example.com/v1/auth/oauth/authorize?") {
+		t.Fatalf("unexpected authorize URL: %s", u)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := parsed.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("scope") != "a b" || q.Get("state") != "st" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// Test vector from RFC 7636 Appendix B.
+	got := pkceChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Fatalf("unexpected challenge: %s", got)
+	}
+}
@@ -0,0 +1,289 @@
+package registry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name of the plugin manifest at the root of a plugin
+// directory and of every packaged archive.
+const ManifestFile = "plugin.yaml"
+
+// defaultPackageExcludes are never packaged.
+var defaultPackageExcludes = []string{".git", ".DS_Store", "*.tar.gz"}
+
+// PackageOptions configures PackagePlugin.
+type PackageOptions struct {
+	// Platform is the target platform, one of SupportedPlatforms. Files under
+	// bin/<platform>/ for any other platform are left out of the archive.
+	Platform string
+	// Meta, if set, is embedded as plugin.yaml instead of the manifest in the
+	// plugin directory.
+	Meta *PluginMeta
+	// Exclude lists additional path.Match patterns. A pattern matches an
+	// entry's base name or its slash-separated path relative to the plugin
+	// directory; excluded directories are skipped entirely.
+	Exclude []string
+}
+
+// PackagePlugin writes a reproducible .tar.gz of the plugin in dir to w and
+// returns its checksum and size. Archives built from the same files are
+// byte-for-byte identical on any machine: entries are sorted, modification
+// times, owners and groups are zeroed, and permissions are normalized to 0755
+// for directories and executables and 0644 for other files.
+//
+// The manifest is parsed, validated and re-encoded as plugin.yaml at the
+// archive root.
+func PackagePlugin(dir string, w io.Writer, opts PackageOptions) (*Artifact, error) {
+	if !slices.Contains(SupportedPlatforms, opts.Platform) {
+		return nil, &FieldError{Field: "platform", Message: fmt.Sprintf("%q is not a supported platform", opts.Platform)}
+	}
+
+	meta := opts.Meta
+	if meta == nil {
+		var err error
+		if meta, err = ReadPluginManifest(filepath.Join(dir, ManifestFile)); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateManifest(meta); err != nil {
+		return nil, err
+	}
+	manifest, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	entries, err := collectPackageEntries(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	cw := &countingWriter{w: io.MultiWriter(w, h)}
+	gz, err := gzip.NewWriterLevel(cw, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	tw := tar.NewWriter(gz)
+
+	if err := writeTarEntry(tw, &tar.Header{Name: ManifestFile, Mode: 0o644, Size: int64(len(manifest))}, strings.NewReader(string(manifest))); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := e.write(tw); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("finishing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("finishing archive: %w", err)
+	}
+	return &Artifact{Checksum: hex.EncodeToString(h.Sum(nil)), Size: cw.n}, nil
+}
+
+// PackagePluginFile packages dir like PackagePlugin and writes the archive to
+// path atomically.
+func PackagePluginFile(dir, path string, opts PackageOptions) (*Artifact, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), ".omniview-package-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating archive: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	a, err := PackagePlugin(dir, f, opts)
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("writing archive: %w", cerr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return nil, fmt.Errorf("writing archive: %w", err)
+	}
+	return a, nil
+}
+
+// ReadPluginManifest reads and decodes a plugin.yaml file.
+func ReadPluginManifest(path string) (*PluginMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var meta PluginMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	return &meta, nil
+}
+
+func validateManifest(meta *PluginMeta) error {
+	var errs []error
+	if meta.ID == "" {
+		errs = append(errs, &FieldError{Field: "id", Message: "is required"})
+	}
+	if _, err := parseSemver(meta.Version); err != nil {
+		errs = append(errs, &FieldError{Field: "version", Message: "must be a semantic version"})
+	}
+	return errors.Join(errs...)
+}
+
+// packageEntry is a file, directory or symlink to be archived.
+type packageEntry struct {
+	name     string // slash-separated path inside the archive
+	src      string
+	mode     fs.FileMode
+	size     int64
+	linkname string
+}
+
+func (e *packageEntry) write(tw *tar.Writer) error {
+	hdr := &tar.Header{Name: e.name}
+	switch {
+	case e.mode.IsDir():
+		hdr.Typeflag, hdr.Name, hdr.Mode = tar.TypeDir, e.name+"/", 0o755
+		return writeTarEntry(tw, hdr, nil)
+	case e.mode&fs.ModeSymlink != 0:
+		hdr.Typeflag, hdr.Linkname, hdr.Mode = tar.TypeSymlink, e.linkname, 0o777
+		return writeTarEntry(tw, hdr, nil)
+	}
+
+	hdr.Typeflag, hdr.Size, hdr.Mode = tar.TypeReg, e.size, 0o644
+	if e.mode&0o111 != 0 {
+		hdr.Mode = 0o755
+	}
+	f, err := os.Open(e.src)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", e.name, err)
+	}
+	defer f.Close()
+	return writeTarEntry(tw, hdr, f)
+}
+
+// writeTarEntry writes hdr with owner, group and times cleared, followed by
+// the contents of r if non-nil.
+func writeTarEntry(tw *tar.Writer, hdr *tar.Header, r io.Reader) error {
+	hdr.ModTime = time.Unix(0, 0)
+	hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+	hdr.Format = tar.FormatPAX
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing %s: %w", hdr.Name, err)
+	}
+	if r != nil {
+		if _, err := io.Copy(tw, r); err != nil {
+			return fmt.Errorf("writing %s: %w", hdr.Name, err)
+		}
+	}
+	return nil
+}
+
+// collectPackageEntries walks dir and returns the entries to archive sorted
+// by name. The manifest is excluded because it is re-encoded separately.
+func collectPackageEntries(dir string, opts PackageOptions) ([]*packageEntry, error) {
+	excludes := append(slices.Clone(defaultPackageExcludes), opts.Exclude...)
+	var entries []*packageEntry
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if name == ManifestFile || excludedFromPackage(name, excludes) || otherPlatformBinary(name, opts.Platform) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		e := &packageEntry{name: name, src: p, mode: info.Mode(), size: info.Size()}
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			if filepath.IsAbs(target) || !fs.ValidPath(path.Join(path.Dir(name), filepath.ToSlash(target))) {
+				return fmt.Errorf("symlink %s points outside the plugin directory", name)
+			}
+			e.linkname = filepath.ToSlash(target)
+		case !info.Mode().IsDir() && !info.Mode().IsRegular():
+			return fmt.Errorf("cannot package %s: unsupported file type", name)
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading plugin directory: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+func excludedFromPackage(name string, patterns []string) bool {
+	base := path.Base(name)
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, base); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// otherPlatformBinary reports whether name lies under bin/<platform>/ for a
+// supported platform other than the target.
+func otherPlatformBinary(name, platform string) bool {
+	rest, ok := strings.CutPrefix(name, "bin/")
+	if !ok {
+		return false
+	}
+	dir, _, _ := strings.Cut(rest, "/")
+	return dir != platform && slices.Contains(SupportedPlatforms, dir)
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,213 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testManifest = `id: test-plugin
+version: 1.2.0
+name: Test Plugin
+description: A plugin for tests
+`
+
+// writePluginDir creates a plugin directory with a manifest, assets and
+// binaries for two platforms. perm is applied to regular files so callers can
+// vary it between builds.
+func writePluginDir(t *testing.T, perm os.FileMode, mtime time.Time) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"plugin.yaml":                testManifest,
+		"assets/icon.svg":            "<svg/>",
+		"README.md":                  "# Test",
+		"bin/linux_amd64/plugin":     "linux binary",
+		"bin/darwin_arm64/plugin":    "darwin binary",
+		".git/HEAD":                  "ref: refs/heads/main",
+		"dist/old-build.tar.gz":      "stale",
+		"ui/dist/index.js":           "console.log(1)",
+		"ui/node_modules/x/index.js": "dep",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		mode := perm
+		if strings.HasPrefix(name, "bin/") {
+			mode |= 0o100
+		}
+		if err := os.WriteFile(p, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(p, mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+type tarEntry struct {
+	name    string
+	mode    int64
+	content string
+	hdr     *tar.Header
+}
+
+func readArchive(t *testing.T, data []byte) []tarEntry {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var entries []tarEntry
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(tr)
+		entries = append(entries, tarEntry{name: hdr.Name, mode: hdr.Mode, content: string(content), hdr: hdr})
+	}
+}
+
+func TestPackagePlugin_reproducible(t *testing.T) {
+	opts := PackageOptions{Platform: "linux_amd64", Exclude: []string{"node_modules"}}
+
+	var a, b bytes.Buffer
+	dirA := writePluginDir(t, 0o644, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	dirB := writePluginDir(t, 0o600, time.Date(2026, 6, 1, 12, 30, 0, 0, time.UTC))
+
+	artA, err := PackagePlugin(dirA, &a, opts)
+	if err != nil {
+		t.Fatalf("PackagePlugin() error: %v", err)
+	}
+	artB, err := PackagePlugin(dirB, &b, opts)
+	if err != nil {
+		t.Fatalf("PackagePlugin() error: %v", err)
+	}
+
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Fatal("archives differ between builds")
+	}
+	sum := sha256.Sum256(a.Bytes())
+	if artA.Checksum != hex.EncodeToString(sum[:]) || artA.Size != int64(a.Len()) {
+		t.Fatalf("unexpected artifact: %+v", artA)
+	}
+	if !reflect.DeepEqual(artA, artB) {
+		t.Fatalf("artifacts differ: %+v vs %+v", artA, artB)
+	}
+}
+
+func TestPackagePlugin_contents(t *testing.T) {
+	dir := writePluginDir(t, 0o640, time.Now())
+	var buf bytes.Buffer
+	if _, err := PackagePlugin(dir, &buf, PackageOptions{Platform: "linux_amd64", Exclude: []string{"ui/node_modules"}}); err != nil {
+		t.Fatalf("PackagePlugin() error: %v", err)
+	}
+
+	var names []string
+	for _, e := range readArchive(t, buf.Bytes()) {
+		names = append(names, e.name)
+		if !e.hdr.ModTime.Equal(time.Unix(0, 0)) || e.hdr.Uid != 0 || e.hdr.Gid != 0 || e.hdr.Uname != "" {
+			t.Errorf("%s: ownership or mtime not normalized: %+v", e.name, e.hdr)
+		}
+		switch {
+		case strings.HasSuffix(e.name, "/"):
+			if e.mode != 0o755 {
+				t.Errorf("%s: expected dir mode 0755, got %o", e.name, e.mode)
+			}
+		case strings.HasPrefix(e.name, "bin/"):
+			if e.mode != 0o755 {
+				t.Errorf("%s: expected executable mode 0755, got %o", e.name, e.mode)
+			}
+		default:
+			if e.mode != 0o644 {
+				t.Errorf("%s: expected file mode 0644, got %o", e.name, e.mode)
+			}
+		}
+	}
+
+	want := []string{
+		"plugin.yaml",
+		"README.md",
+		"assets/", "assets/icon.svg",
+		"bin/", "bin/linux_amd64/", "bin/linux_amd64/plugin",
+		"dist/",
+		"ui/", "ui/dist/", "ui/dist/index.js",
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected entries:\n got %v\nwant %v", names, want)
+	}
+}
+
+func TestPackagePlugin_metaOverride(t *testing.T) {
+	dir := writePluginDir(t, 0o644, time.Now())
+	var buf bytes.Buffer
+	meta := &PluginMeta{ID: "test-plugin", Version: "2.0.0", Name: "Override"}
+	if _, err := PackagePlugin(dir, &buf, PackageOptions{Platform: "darwin_arm64", Meta: meta}); err != nil {
+		t.Fatalf("PackagePlugin() error: %v", err)
+	}
+	entries := readArchive(t, buf.Bytes())
+	if entries[0].name != ManifestFile || !strings.Contains(entries[0].content, "version: 2.0.0") {
+		t.Fatalf("expected embedded override manifest, got %q", entries[0].content)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.name, "bin/linux_amd64") {
+			t.Fatalf("other platform binary packaged: %s", e.name)
+		}
+	}
+}
+
+func TestPackagePlugin_invalid(t *testing.T) {
+	dir := writePluginDir(t, 0o644, time.Now())
+
+	if _, err := PackagePlugin(dir, io.Discard, PackageOptions{Platform: "plan9_386"}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected invalid platform error, got %v", err)
+	}
+	if _, err := PackagePlugin(dir, io.Discard, PackageOptions{Platform: "linux_amd64", Meta: &PluginMeta{ID: "x", Version: "latest"}}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected invalid version error, got %v", err)
+	}
+
+	if err := os.Symlink("../../etc/passwd", filepath.Join(dir, "assets", "escape")); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+	if _, err := PackagePlugin(dir, io.Discard, PackageOptions{Platform: "linux_amd64"}); err == nil || !strings.Contains(err.Error(), "outside the plugin directory") {
+		t.Fatalf("expected escaping symlink error, got %v", err)
+	}
+}
+
+func TestPackagePluginFile(t *testing.T) {
+	dir := writePluginDir(t, 0o644, time.Now())
+	out := filepath.Join(t.TempDir(), "test-plugin-linux_amd64.tar.gz")
+
+	art, err := PackagePluginFile(dir, out, PackageOptions{Platform: "linux_amd64"})
+	if err != nil {
+		t.Fatalf("PackagePluginFile() error: %v", err)
+	}
+	local, err := hashArtifact(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(local.sha256) != art.Checksum || local.size != art.Size {
+		t.Fatalf("file does not match artifact: %+v", art)
+	}
+}
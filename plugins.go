@@ -36,3 +36,129 @@ func (c *Client) ListCategories(ctx context.Context) ([]CategoryCount, error) {
 	}
 	return cats, nil
 }
+
+// CreatePluginRequest is the request body for registering a new plugin ID
+// under a publisher.
+type CreatePluginRequest struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Repository  string   `json:"repository,omitempty"`
+	URL         string   `json:"url,omitempty"`
+}
+
+// UpdatePluginRequest is the request body for a partial update of a plugin's
+// listing. Nil fields are left unchanged.
+type UpdatePluginRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	IconURL     *string   `json:"icon_url,omitempty"`
+	Category    *string   `json:"category,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+	License     *string   `json:"license,omitempty"`
+	Repository  *string   `json:"repository,omitempty"`
+	URL         *string   `json:"url,omitempty"`
+	Unlisted    *bool     `json:"unlisted,omitempty"`
+}
+
+// UpdatePluginRequestFromMeta returns an update that sets every listing field
+// from meta, as read from a plugin's manifest.
+func UpdatePluginRequestFromMeta(meta *PluginMeta) *UpdatePluginRequest {
+	tags := append([]string{}, meta.Tags...)
+	return &UpdatePluginRequest{
+		Name:        &meta.Name,
+		Description: &meta.Description,
+		IconURL:     &meta.IconURL,
+		Category:    &meta.Category,
+		Tags:        &tags,
+		License:     &meta.License,
+		Repository:  &meta.Repository,
+		URL:         &meta.Website,
+	}
+}
+
+// DeprecatePluginRequest is the request body for deprecating a plugin.
+type DeprecatePluginRequest struct {
+	Message       string `json:"message,omitempty"`
+	ReplacementID string `json:"replacement_id,omitempty"`
+}
+
+// TransferPluginRequest is the request body for moving a plugin to another publisher.
+type TransferPluginRequest struct {
+	ToPublisher string `json:"to_publisher"`
+}
+
+// CreatePlugin registers a new plugin ID under a publisher.
+// Requires the manage_plugins permission.
+func (c *Client) CreatePlugin(ctx context.Context, publisherSlug string, req *CreatePluginRequest) (*Plugin, error) {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionManagePlugins); err != nil {
+		return nil, err
+	}
+	var p Plugin
+	path := fmt.Sprintf("/v1/publishers/%s/plugins", publisherSlug)
+	if err := c.post(ctx, path, req, &p); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionManagePlugins)
+	}
+	return &p, nil
+}
+
+// UpdatePlugin applies a partial update to a plugin's listing.
+// Requires the manage_plugins permission.
+func (c *Client) UpdatePlugin(ctx context.Context, pluginID string, req *UpdatePluginRequest) (*Plugin, error) {
+	var p Plugin
+	if err := c.patch(ctx, fmt.Sprintf("/v1/plugins/%s", pluginID), req, &p); err != nil {
+		return nil, asPermissionError(err, "", pluginID, PermissionManagePlugins)
+	}
+	return &p, nil
+}
+
+// UnlistPlugin hides a plugin from listings and search. Existing installs and
+// direct lookups keep working.
+func (c *Client) UnlistPlugin(ctx context.Context, pluginID string) (*Plugin, error) {
+	unlisted := true
+	return c.UpdatePlugin(ctx, pluginID, &UpdatePluginRequest{Unlisted: &unlisted})
+}
+
+// RelistPlugin makes an unlisted plugin visible in listings again.
+func (c *Client) RelistPlugin(ctx context.Context, pluginID string) (*Plugin, error) {
+	unlisted := false
+	return c.UpdatePlugin(ctx, pluginID, &UpdatePluginRequest{Unlisted: &unlisted})
+}
+
+// DeprecatePlugin marks a plugin as deprecated, optionally pointing users at a
+// replacement plugin. Requires the manage_plugins permission.
+func (c *Client) DeprecatePlugin(ctx context.Context, pluginID string, req *DeprecatePluginRequest) (*Plugin, error) {
+	if req.ReplacementID == pluginID {
+		return nil, &FieldError{Field: "replacement_id", Message: "must differ from the deprecated plugin"}
+	}
+	var p Plugin
+	if err := c.post(ctx, fmt.Sprintf("/v1/plugins/%s/deprecate", pluginID), req, &p); err != nil {
+		return nil, asPermissionError(err, "", pluginID, PermissionManagePlugins)
+	}
+	return &p, nil
+}
+
+// TransferPlugin moves a plugin to another publisher. Requires the
+// manage_plugins permission on the current publisher.
+func (c *Client) TransferPlugin(ctx context.Context, pluginID string, req *TransferPluginRequest) (*Plugin, error) {
+	if err := ValidatePublisherSlug(req.ToPublisher); err != nil {
+		return nil, err
+	}
+	var p Plugin
+	if err := c.post(ctx, fmt.Sprintf("/v1/plugins/%s/transfer", pluginID), req, &p); err != nil {
+		return nil, asPermissionError(err, "", pluginID, PermissionManagePlugins)
+	}
+	return &p, nil
+}
+
+// DeletePlugin permanently deletes a plugin and all of its versions.
+// Requires the manage_plugins permission.
+func (c *Client) DeletePlugin(ctx context.Context, pluginID string) error {
+	if err := c.del(ctx, fmt.Sprintf("/v1/plugins/%s", pluginID), nil); err != nil {
+		return asPermissionError(err, "", pluginID, PermissionManagePlugins)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func fakePluginLifecycleAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/publishers/omniview/plugins", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var body CreatePluginRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": body.ID, "name": body.Name, "publisher_id": "pub-1"},
+		})
+	})
+
+	mux.HandleFunc("/v1/plugins/my-plugin", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			data := map[string]interface{}{"id": "my-plugin"}
+			for k, v := range body {
+				data[k] = v
+			}
+			writeJSON(w, map[string]interface{}{"success": true, "data": data})
+		case http.MethodDelete:
+			writeJSON(w, map[string]interface{}{"success": true})
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/v1/plugins/my-plugin/deprecate", func(w http.ResponseWriter, r *http.Request) {
+		var body DeprecatePluginRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data": map[string]interface{}{
+				"id":                  "my-plugin",
+				"deprecated":          true,
+				"deprecation_message": body.Message,
+				"replacement_id":      body.ReplacementID,
+			},
+		})
+	})
+
+	mux.HandleFunc("/v1/plugins/my-plugin/transfer", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": "my-plugin", "publisher_id": "pub-2"},
+		})
+	})
+
+	mux.HandleFunc("/v1/plugins/not-mine", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "not a maintainer of this plugin"})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestClient_CreatePlugin(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	p, err := c.CreatePlugin(context.Background(), "omniview", &CreatePluginRequest{ID: "my-plugin", Name: "My Plugin"})
+	if err != nil {
+		t.Fatalf("CreatePlugin() error: %v", err)
+	}
+	if p.ID != "my-plugin" || p.PublisherID != "pub-1" {
+		t.Fatalf("unexpected plugin: %+v", p)
+	}
+}
+
+func TestClient_CreatePlugin_deniedLocally(t *testing.T) {
+	c := NewClient(WithBaseURL("http://127.0.0.1:1"))
+	c.rememberAccess(context.Background(), "omniview", PublisherAccess{Slug: "omniview", Role: "viewer"})
+
+	_, err := c.CreatePlugin(context.Background(), "omniview", &CreatePluginRequest{ID: "my-plugin"})
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) || permErr.Permission != PermissionManagePlugins {
+		t.Fatalf("expected manage_plugins PermissionError, got %v", err)
+	}
+}
+
+func TestClient_UpdatePlugin_fromMeta(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	meta := &PluginMeta{
+		ID:          "my-plugin",
+		Name:        "My Plugin",
+		Description: "Does things",
+		Category:    "cloud",
+		Tags:        []string{"aws"},
+		Website:     "https://example.com",
+	}
+	p, err := c.UpdatePlugin(context.Background(), "my-plugin", UpdatePluginRequestFromMeta(meta))
+	if err != nil {
+		t.Fatalf("UpdatePlugin() error: %v", err)
+	}
+	if p.Name != "My Plugin" || p.Category != "cloud" || p.URL != "https://example.com" {
+		t.Fatalf("unexpected plugin: %+v", p)
+	}
+	if len(p.Tags) != 1 || p.Tags[0] != "aws" {
+		t.Fatalf("unexpected tags: %v", p.Tags)
+	}
+}
+
+func TestClient_UnlistPlugin(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	p, err := c.UnlistPlugin(context.Background(), "my-plugin")
+	if err != nil {
+		t.Fatalf("UnlistPlugin() error: %v", err)
+	}
+	if !p.Unlisted {
+		t.Fatal("expected plugin to be unlisted")
+	}
+}
+
+func TestClient_DeprecatePlugin(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	p, err := c.DeprecatePlugin(context.Background(), "my-plugin", &DeprecatePluginRequest{
+		Message:       "Use the new one",
+		ReplacementID: "my-plugin-v2",
+	})
+	if err != nil {
+		t.Fatalf("DeprecatePlugin() error: %v", err)
+	}
+	if !p.Deprecated || p.ReplacementID != "my-plugin-v2" {
+		t.Fatalf("unexpected plugin: %+v", p)
+	}
+
+	_, err = c.DeprecatePlugin(context.Background(), "my-plugin", &DeprecatePluginRequest{ReplacementID: "my-plugin"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected self-replacement to be rejected, got %v", err)
+	}
+}
+
+func TestClient_TransferPlugin(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	p, err := c.TransferPlugin(context.Background(), "my-plugin", &TransferPluginRequest{ToPublisher: "acme"})
+	if err != nil {
+		t.Fatalf("TransferPlugin() error: %v", err)
+	}
+	if p.PublisherID != "pub-2" {
+		t.Fatalf("expected pub-2, got %s", p.PublisherID)
+	}
+}
+
+func TestClient_DeletePlugin(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if err := c.DeletePlugin(context.Background(), "my-plugin"); err != nil {
+		t.Fatalf("DeletePlugin() error: %v", err)
+	}
+}
+
+func TestClient_DeletePlugin_forbidden(t *testing.T) {
+	srv := fakePluginLifecycleAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	err := c.DeletePlugin(context.Background(), "not-mine")
+
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("expected PermissionError, got %T: %v", err, err)
+	}
+	if permErr.PluginID != "not-mine" {
+		t.Fatalf("expected plugin ID not-mine, got %q", permErr.PluginID)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "not a maintainer of this plugin" {
+		t.Fatalf("expected wrapped APIError, got %v", err)
+	}
+	if !IsForbidden(err) {
+		t.Fatal("expected IsForbidden")
+	}
+}
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	defaultPublishPollInterval = 10 * time.Second
+	withdrawTimeout            = 30 * time.Second
+)
+
+// PublishInput describes a plugin version to publish.
+type PublishInput struct {
+	PluginID  string
+	Version   string
+	Changelog string
+
+	// Artifacts maps a platform from SupportedPlatforms to the path of its
+	// packaged archive.
+	Artifacts map[string]string
+
+	// Wait polls the submission after it is submitted until it is approved
+	// or reaches a terminal status.
+	Wait bool
+	// PollInterval is the wait between polls. Defaults to 10s.
+	PollInterval time.Duration
+	// Retry controls artifact upload retries. Defaults to three attempts.
+	Retry *RetryPolicy
+}
+
+// Validate checks the input before anything is sent.
+func (in *PublishInput) Validate() error {
+	var errs []error
+	if in.PluginID == "" {
+		errs = append(errs, &FieldError{Field: "plugin_id", Message: "is required"})
+	}
+	if _, err := parseSemver(in.Version); err != nil {
+		errs = append(errs, &FieldError{Field: "version", Message: "must be a semantic version"})
+	}
+	if len(in.Artifacts) == 0 {
+		errs = append(errs, &FieldError{Field: "artifacts", Message: "must contain at least one platform"})
+	}
+	for _, platform := range sortedKeys(in.Artifacts) {
+		if !slices.Contains(SupportedPlatforms, platform) {
+			errs = append(errs, &FieldError{Field: "artifacts", Message: fmt.Sprintf("has unsupported platform %q", platform)})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishError is returned when Publish fails after the submission was
+// created. Withdrawn reports whether the submission was withdrawn as a result.
+type PublishError struct {
+	SubmissionID string
+	Stage        string
+	Withdrawn    bool
+	Err          error
+}
+
+func (e *PublishError) Error() string {
+	msg := fmt.Sprintf("publish %s failed for submission %s: %v", e.Stage, e.SubmissionID, e.Err)
+	if e.Withdrawn {
+		msg += " (submission withdrawn)"
+	}
+	return msg
+}
+
+func (e *PublishError) Unwrap() error {
+	return e.Err
+}
+
+// Publish creates a submission for in, uploads every artifact with an
+// Uploader and submits the submission for review. If in.Wait is set it
+// then polls until the submission is approved, reaches a terminal status or
+// reports a status this package does not know.
+//
+// If uploading or submitting fails, or ctx is cancelled, the submission is
+// withdrawn unless its last known status no longer allows it, and a
+// *PublishError is returned. Failed polls are retried; if several fail in a
+// row the last error is returned in a *PublishError and the submission is
+// left as it is.
+func (c *Client) Publish(ctx context.Context, publisherSlug string, in PublishInput) (*Submission, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	platforms := sortedKeys(in.Artifacts)
+	artifacts := make(map[string]*localArtifact, len(platforms))
+	for _, platform := range platforms {
+		a, err := hashArtifact(in.Artifacts[platform])
+		if err != nil {
+			return nil, err
+		}
+		artifacts[platform] = a
+	}
+
+	sub, err := c.CreateSubmission(ctx, publisherSlug, &CreateSubmissionRequest{
+		PluginID:  in.PluginID,
+		Version:   in.Version,
+		Changelog: in.Changelog,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating submission: %w", err)
+	}
+
+	id, status := sub.ID, sub.Status
+
+	u := c.NewUploader()
+	if in.Retry != nil {
+		u.Retry = *in.Retry
+	}
+	for _, platform := range platforms {
+		if err := u.upload(ctx, id, platform, artifacts[platform]); err != nil {
+			return nil, c.abortPublish(ctx, id, status, "upload", fmt.Errorf("%s: %w", platform, err))
+		}
+	}
+
+	sub, err = c.SubmitForReview(ctx, id)
+	if err != nil {
+		return nil, c.abortPublish(ctx, id, status, "submit", err)
+	}
+	status = sub.Status
+	if !in.Wait {
+		return sub, nil
+	}
+
+	interval := in.PollInterval
+	if interval <= 0 {
+		interval = defaultPublishPollInterval
+	}
+	failures := 0
+	for !publishSettled(sub.Status) {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, c.abortPublish(ctx, id, sub.Status, "wait", ctx.Err())
+		}
+		next, err := c.GetSubmission(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, c.abortPublish(ctx, id, sub.Status, "wait", ctx.Err())
+			}
+			if failures++; failures >= watchMaxPollErrors {
+				return nil, &PublishError{SubmissionID: id, Stage: "wait", Err: err}
+			}
+			continue
+		}
+		failures = 0
+		sub = next
+	}
+	return sub, nil
+}
+
+// publishSettled reports whether Publish can stop waiting on a submission.
+// Approved submissions are accepted even if the registry has not yet marked
+// them published.
+func publishSettled(s SubmissionStatus) bool {
+	return s.IsTerminal() || s == SubmissionApproved || !s.Known()
+}
+
+// abortPublish withdraws a submission after a failed publish step, unless
+// its last known status cannot be withdrawn. The withdrawal runs even if ctx
+// has been cancelled.
+func (c *Client) abortPublish(ctx context.Context, id string, status SubmissionStatus, stage string, cause error) error {
+	if !status.CanWithdraw() {
+		return &PublishError{SubmissionID: id, Stage: stage, Err: cause}
+	}
+	wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), withdrawTimeout)
+	defer cancel()
+	_, werr := c.WithdrawSubmission(wctx, id)
+	return &PublishError{SubmissionID: id, Stage: stage, Withdrawn: werr == nil, Err: cause}
+}
@@ -0,0 +1,302 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePublishAPI serves the submission endpoints used by Publish and accepts
+// artifact uploads at /upload/{platform}. failUploads makes the first n upload
+// attempts answer with the given status.
+type fakePublishAPI struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	uploads     map[string][]byte
+	checksums   map[string]string
+	uploadCalls int
+	failUploads int
+	failStatus  int
+	status      SubmissionStatus
+	polls       int
+	failPolls   int
+	withdrawn   bool
+}
+
+func newFakePublishAPI(t *testing.T) *fakePublishAPI {
+	t.Helper()
+	f := &fakePublishAPI{uploads: map[string][]byte{}, checksums: map[string]string{}, status: SubmissionDraft}
+	mux := http.NewServeMux()
+
+	sub := func() map[string]interface{} {
+		return map[string]interface{}{"id": "sub-1", "plugin_id": "test-plugin", "version": "1.0.0", "status": f.status}
+	}
+
+	mux.HandleFunc("/v1/publishers/acme/submissions", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		writeJSON(w, map[string]interface{}{"success": true, "data": sub()})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/upload-urls", func(w http.ResponseWriter, r *http.Request) {
+		var req UploadURLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		urls := map[string]string{}
+		for _, p := range req.Architectures {
+			urls[p] = f.URL + "/upload/" + p
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"urls": urls}})
+	})
+	mux.HandleFunc("/upload/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.uploadCalls++
+		if r.Header.Get("Authorization") != "" {
+			t.Error("credentials must not be sent to presigned URLs")
+		}
+		if f.failUploads > 0 {
+			f.failUploads--
+			w.WriteHeader(f.failStatus)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		platform := filepath.Base(r.URL.Path)
+		f.uploads[platform] = body
+		f.checksums[platform] = r.Header.Get("X-Checksum-Sha256")
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/submit", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.status = SubmissionPendingReview
+		writeJSON(w, map[string]interface{}{"success": true, "data": sub()})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/withdraw", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.withdrawn = true
+		f.status = SubmissionWithdrawn
+		writeJSON(w, map[string]interface{}{"success": true, "data": sub()})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.failPolls > 0 {
+			f.failPolls--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			writeJSON(w, map[string]interface{}{"success": false, "message": "unavailable"})
+			return
+		}
+		f.polls++
+		if f.polls >= 2 && f.status == SubmissionPendingReview {
+			f.status = SubmissionApproved
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": sub()})
+	})
+
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+func writeArtifact(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugin.tar.gz")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fastRetry() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+}
+
+func TestClient_Publish(t *testing.T) {
+	f := newFakePublishAPI(t)
+	defer f.Close()
+	f.failUploads, f.failStatus = 1, http.StatusServiceUnavailable
+
+	c := NewClient(WithBaseURL(f.URL), WithAPIKey("key"))
+	sub, err := c.Publish(context.Background(), "acme", PublishInput{
+		PluginID: "test-plugin",
+		Version:  "1.0.0",
+		Artifacts: map[string]string{
+			"linux_amd64":  writeArtifact(t, "linux build"),
+			"darwin_arm64": writeArtifact(t, "darwin build"),
+		},
+		Wait:         true,
+		PollInterval: time.Millisecond,
+		Retry:        fastRetry(),
+	})
+	if err != nil {
+		t.Fatalf("Publish() error: %v", err)
+	}
+	if sub.Status != SubmissionApproved {
+		t.Fatalf("expected approved, got %s", sub.Status)
+	}
+	if string(f.uploads["linux_amd64"]) != "linux build" || string(f.uploads["darwin_arm64"]) != "darwin build" {
+		t.Fatalf("unexpected uploads: %v", f.uploads)
+	}
+	sum := sha256.Sum256([]byte("linux build"))
+	if f.checksums["linux_amd64"] != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected checksum header: %s", f.checksums["linux_amd64"])
+	}
+	if f.uploadCalls != 3 {
+		t.Fatalf("expected one retried upload (3 calls), got %d", f.uploadCalls)
+	}
+	if f.withdrawn {
+		t.Fatal("successful publish should not withdraw")
+	}
+}
+
+func TestClient_Publish_uploadFailureWithdraws(t *testing.T) {
+	f := newFakePublishAPI(t)
+	defer f.Close()
+	f.failUploads, f.failStatus = 100, http.StatusForbidden
+
+	c := NewClient(WithBaseURL(f.URL), WithAPIKey("key"))
+	_, err := c.Publish(context.Background(), "acme", PublishInput{
+		PluginID:  "test-plugin",
+		Version:   "1.0.0",
+		Artifacts: map[string]string{"linux_amd64": writeArtifact(t, "build")},
+		Retry:     fastRetry(),
+	})
+
+	var pubErr *PublishError
+	if !errors.As(err, &pubErr) {
+		t.Fatalf("expected PublishError, got %v", err)
+	}
+	if pubErr.Stage != "upload" || !pubErr.Withdrawn || !f.withdrawn {
+		t.Fatalf("expected withdrawn upload failure, got %+v", pubErr)
+	}
+	if f.uploadCalls != 1 {
+		t.Fatalf("403 should not be retried, got %d calls", f.uploadCalls)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected wrapped APIError, got %v", err)
+	}
+}
+
+func TestClient_Publish_cancelWhileWaitingWithdraws(t *testing.T) {
+	f := newFakePublishAPI(t)
+	defer f.Close()
+
+	c := NewClient(WithBaseURL(f.URL), WithAPIKey("key"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Publish(ctx, "acme", PublishInput{
+		PluginID:     "test-plugin",
+		Version:      "1.0.0",
+		Artifacts:    map[string]string{"linux_amd64": writeArtifact(t, "build")},
+		Wait:         true,
+		PollInterval: time.Hour,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if !f.withdrawn {
+		t.Fatal("expected submission to be withdrawn after cancellation")
+	}
+}
+
+func TestClient_Publish_pollFailures(t *testing.T) {
+	publish := func(f *fakePublishAPI) (*Submission, error) {
+		c := NewClient(WithBaseURL(f.URL), WithAPIKey("key"))
+		return c.Publish(context.Background(), "acme", PublishInput{
+			PluginID:     "test-plugin",
+			Version:      "1.0.0",
+			Artifacts:    map[string]string{"linux_amd64": writeArtifact(t, "build")},
+			Wait:         true,
+			PollInterval: time.Millisecond,
+		})
+	}
+
+	t.Run("transient", func(t *testing.T) {
+		f := newFakePublishAPI(t)
+		defer f.Close()
+		f.failPolls = 1
+
+		sub, err := publish(f)
+		if err != nil {
+			t.Fatalf("Publish() error: %v", err)
+		}
+		if sub.Status != SubmissionApproved || f.withdrawn {
+			t.Fatalf("expected approved, unwithdrawn submission, got %s (withdrawn %v)", sub.Status, f.withdrawn)
+		}
+	})
+
+	t.Run("persistent", func(t *testing.T) {
+		f := newFakePublishAPI(t)
+		defer f.Close()
+		f.failPolls = 100
+
+		_, err := publish(f)
+		var pubErr *PublishError
+		if !errors.As(err, &pubErr) || pubErr.Stage != "wait" || pubErr.Withdrawn {
+			t.Fatalf("expected unwithdrawn wait PublishError, got %v", err)
+		}
+		if f.withdrawn {
+			t.Fatal("a submission pending review must not be withdrawn because polling failed")
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("expected wrapped 503 APIError, got %v", err)
+		}
+	})
+}
+
+func TestClient_abortPublish_skipsUnwithdrawable(t *testing.T) {
+	f := newFakePublishAPI(t)
+	defer f.Close()
+
+	c := NewClient(WithBaseURL(f.URL), WithAPIKey("key"))
+	err := c.abortPublish(context.Background(), "sub-1", SubmissionApproved, "wait", context.DeadlineExceeded)
+	var pubErr *PublishError
+	if !errors.As(err, &pubErr) || pubErr.Withdrawn {
+		t.Fatalf("expected unwithdrawn PublishError, got %v", err)
+	}
+	if f.withdrawn {
+		t.Fatal("approved submission must not be withdrawn")
+	}
+}
+
+func TestPublishSettled(t *testing.T) {
+	for _, s := range []SubmissionStatus{SubmissionApproved, SubmissionPublished, SubmissionValidationFailed, SubmissionRejected, "quarantined"} {
+		if !publishSettled(s) {
+			t.Errorf("publishSettled(%q) = false", s)
+		}
+	}
+	for _, s := range []SubmissionStatus{SubmissionValidating, SubmissionPendingReview} {
+		if publishSettled(s) {
+			t.Errorf("publishSettled(%q) = true", s)
+		}
+	}
+}
+
+func TestPublishInput_Validate(t *testing.T) {
+	err := (&PublishInput{Version: "latest", Artifacts: map[string]string{"plan9_386": "x"}}).Validate()
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatal("expected FieldError")
+	}
+	for _, want := range []string{"plugin_id", "version", "unsupported platform"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
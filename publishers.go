@@ -1,8 +1,18 @@
 package registry
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 // GetPublisher returns a publisher by slug.
@@ -15,11 +25,15 @@ func (c *Client) GetPublisher(ctx context.Context, slug string) (*Publisher, err
 }
 
 // CheckPublisherAccess returns the caller's permissions for a publisher.
+// The result is remembered so later calls that need a permission the caller
+// lacks fail with a *PermissionError before reaching the server. Calls made
+// with CallToken or CallAPIKey neither use nor update the remembered access.
 func (c *Client) CheckPublisherAccess(ctx context.Context, slug string) (*PublisherAccess, error) {
 	var access PublisherAccess
 	if err := c.get(ctx, fmt.Sprintf("/v1/publishers/%s/can-i", slug), &access); err != nil {
 		return nil, err
 	}
+	c.rememberAccess(ctx, slug, access)
 	return &access, nil
 }
 
@@ -36,3 +50,244 @@ func (c *Client) ListPublisherPlugins(ctx context.Context, slug string, opts *Li
 	}
 	return ListResult[Plugin]{Items: items, Pagination: pag}, nil
 }
+
+// rememberAccess caches the caller's access for the publisher with the given
+// slug, which is the slug requested rather than the one echoed by the server.
+// Access obtained with per-call credentials belongs to another caller and is
+// not cached.
+func (c *Client) rememberAccess(ctx context.Context, slug string, access PublisherAccess) {
+	if callOptionsFrom(ctx).overridesCredentials() {
+		return
+	}
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	if c.access == nil {
+		c.access = map[string]PublisherAccess{}
+	}
+	c.access[slug] = access
+}
+
+// forgetAccess drops cached access for the given slugs, or for every
+// publisher if none are given.
+func (c *Client) forgetAccess(slugs ...string) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	if len(slugs) == 0 {
+		c.access = nil
+		return
+	}
+	for _, slug := range slugs {
+		delete(c.access, slug)
+	}
+}
+
+// requirePermission returns a *PermissionError if the caller's access to the
+// publisher is known and lacks p. Unknown access, and calls made with
+// per-call credentials, are left to the server.
+func (c *Client) requirePermission(ctx context.Context, slug string, p Permission) error {
+	if callOptionsFrom(ctx).overridesCredentials() {
+		return nil
+	}
+	c.accessMu.Lock()
+	access, ok := c.access[slug]
+	c.accessMu.Unlock()
+	if ok && !access.Has(p) {
+		return &PermissionError{Slug: slug, Role: access.Role, Permission: p}
+	}
+	return nil
+}
+
+const (
+	minSlugLength          = 3
+	maxSlugLength          = 40
+	maxPublisherNameLength = 100
+	maxDescriptionLength   = 500
+	maxLogoSize            = 1 << 20
+	publisherLogoFormField = "logo"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// allowedLogoExtensions lists the image types accepted for publisher logos.
+var allowedLogoExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".svg": true, ".webp": true}
+
+// CreatePublisherRequest is the request body for creating a publisher.
+type CreatePublisherRequest struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Website     string `json:"website,omitempty"`
+}
+
+// Validate checks the request against the registry's publisher rules.
+func (r *CreatePublisherRequest) Validate() error {
+	var errs []error
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, &FieldError{Field: "name", Message: "is required"})
+	} else if utf8.RuneCountInString(r.Name) > maxPublisherNameLength {
+		errs = append(errs, &FieldError{Field: "name", Message: fmt.Sprintf("must be at most %d characters", maxPublisherNameLength)})
+	}
+	if err := ValidatePublisherSlug(r.Slug); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateDescription(r.Description); err != nil {
+		errs = append(errs, err)
+	}
+	if r.Website != "" {
+		if err := ValidateWebsite(r.Website); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UpdatePublisherRequest is the request body for a partial publisher update.
+// Nil fields are left unchanged.
+type UpdatePublisherRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Website     *string `json:"website,omitempty"`
+}
+
+// Validate checks the fields that are set against the registry's publisher rules.
+func (r *UpdatePublisherRequest) Validate() error {
+	var errs []error
+	if r.Name != nil {
+		if strings.TrimSpace(*r.Name) == "" {
+			errs = append(errs, &FieldError{Field: "name", Message: "must not be empty"})
+		} else if utf8.RuneCountInString(*r.Name) > maxPublisherNameLength {
+			errs = append(errs, &FieldError{Field: "name", Message: fmt.Sprintf("must be at most %d characters", maxPublisherNameLength)})
+		}
+	}
+	if r.Description != nil {
+		if err := validateDescription(*r.Description); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// An empty website clears it.
+	if r.Website != nil && *r.Website != "" {
+		if err := ValidateWebsite(*r.Website); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SlugAvailability reports whether a publisher slug can be registered.
+type SlugAvailability struct {
+	Slug        string   `json:"slug"`
+	Available   bool     `json:"available"`
+	Reason      string   `json:"reason,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// ValidatePublisherSlug checks that slug is 3–40 lowercase letters, digits and
+// single hyphens, starting and ending with a letter or digit.
+func ValidatePublisherSlug(slug string) error {
+	if len(slug) < minSlugLength || len(slug) > maxSlugLength {
+		return &FieldError{Field: "slug", Message: fmt.Sprintf("must be between %d and %d characters", minSlugLength, maxSlugLength)}
+	}
+	if !slugPattern.MatchString(slug) {
+		return &FieldError{Field: "slug", Message: "may only contain lowercase letters, digits and single hyphens, and must start and end with a letter or digit"}
+	}
+	return nil
+}
+
+// ValidateWebsite checks that website is an absolute http or https URL.
+func ValidateWebsite(website string) error {
+	u, err := url.Parse(website)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return &FieldError{Field: "website", Message: "must be an absolute http or https URL"}
+	}
+	return nil
+}
+
+func validateDescription(desc string) error {
+	if utf8.RuneCountInString(desc) > maxDescriptionLength {
+		return &FieldError{Field: "description", Message: fmt.Sprintf("must be at most %d characters", maxDescriptionLength)}
+	}
+	return nil
+}
+
+// CheckSlugAvailability reports whether a publisher slug is free to register.
+// Slugs that fail local validation are reported as unavailable without a request.
+func (c *Client) CheckSlugAvailability(ctx context.Context, slug string) (*SlugAvailability, error) {
+	if err := ValidatePublisherSlug(slug); err != nil {
+		return &SlugAvailability{Slug: slug, Available: false, Reason: err.Error()}, nil
+	}
+	var sa SlugAvailability
+	path := "/v1/publishers/slug-availability?slug=" + url.QueryEscape(slug)
+	if err := c.get(ctx, path, &sa); err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
+
+// CreatePublisher creates a publisher owned by the caller.
+func (c *Client) CreatePublisher(ctx context.Context, req *CreatePublisherRequest) (*Publisher, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var p Publisher
+	if err := c.post(ctx, "/v1/publishers", req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UpdatePublisher applies a partial update to a publisher's profile.
+// Requires the manage_members permission.
+func (c *Client) UpdatePublisher(ctx context.Context, slug string, req *UpdatePublisherRequest) (*Publisher, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.requirePermission(ctx, slug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+	var p Publisher
+	if err := c.patch(ctx, fmt.Sprintf("/v1/publishers/%s", slug), req, &p); err != nil {
+		return nil, asPermissionError(err, slug, "", PermissionManageMembers)
+	}
+	return &p, nil
+}
+
+// UploadPublisherLogo uploads a logo image for a publisher. filename is used
+// to determine the image type; PNG, JPEG, SVG and WebP up to 1 MiB are accepted.
+// Requires the manage_members permission.
+func (c *Client) UploadPublisherLogo(ctx context.Context, slug, filename string, r io.Reader) (*Publisher, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !allowedLogoExtensions[ext] {
+		return nil, &FieldError{Field: "logo", Message: fmt.Sprintf("unsupported image type %q", ext)}
+	}
+	if err := c.requirePermission(ctx, slug, PermissionManageMembers); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxLogoSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading logo: %w", err)
+	}
+	if len(data) > maxLogoSize {
+		return nil, &FieldError{Field: "logo", Message: fmt.Sprintf("must be at most %d bytes", maxLogoSize)}
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile(publisherLogoFormField, filepath.Base(filename))
+	if err != nil {
+		return nil, fmt.Errorf("creating multipart body: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, fmt.Errorf("writing multipart body: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("closing multipart body: %w", err)
+	}
+
+	var p Publisher
+	path := fmt.Sprintf("/v1/publishers/%s/logo", slug)
+	if _, err := c.doEnvelope(ctx, http.MethodPut, path, buf.Bytes(), mw.FormDataContentType(), &p); err != nil {
+		return nil, asPermissionError(err, slug, "", PermissionManageMembers)
+	}
+	return &p, nil
+}
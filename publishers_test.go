@@ -0,0 +1,220 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func fakePublisherProfileAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/publishers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var body CreatePublisherRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": "pub-2", "name": body.Name, "slug": body.Slug, "website": body.Website},
+		})
+	})
+
+	mux.HandleFunc("/v1/publishers/slug-availability", func(w http.ResponseWriter, r *http.Request) {
+		slug := r.URL.Query().Get("slug")
+		data := map[string]interface{}{"slug": slug, "available": true}
+		if slug == "taken" {
+			data = map[string]interface{}{"slug": slug, "available": false, "suggestions": []string{"taken-2"}}
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": data})
+	})
+
+	mux.HandleFunc("/v1/publishers/acme", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["name"]; ok {
+			http.Error(w, "unexpected name in partial update", http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": "pub-2", "name": "Acme", "slug": "acme", "description": body["description"]},
+		})
+	})
+
+	mux.HandleFunc("/v1/publishers/acme/logo", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("logo")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"slug": "acme", "logo": header.Filename + ":" + string(data)},
+		})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestClient_CreatePublisher(t *testing.T) {
+	srv := fakePublisherProfileAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	p, err := c.CreatePublisher(context.Background(), &CreatePublisherRequest{
+		Name:    "Acme",
+		Slug:    "acme",
+		Website: "https://acme.example.com",
+	})
+	if err != nil {
+		t.Fatalf("CreatePublisher() error: %v", err)
+	}
+	if p.Slug != "acme" || p.Website != "https://acme.example.com" {
+		t.Fatalf("unexpected publisher: %+v", p)
+	}
+}
+
+func TestClient_CreatePublisher_invalid(t *testing.T) {
+	c := NewClient(WithBaseURL("http://127.0.0.1:1"))
+	_, err := c.CreatePublisher(context.Background(), &CreatePublisherRequest{
+		Name:        "",
+		Slug:        "Bad_Slug",
+		Website:     "ftp://acme",
+		Description: strings.Repeat("x", maxDescriptionLength+1),
+	})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	for _, field := range []string{"name", "slug", "website", "description"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected error to mention %s: %v", field, err)
+		}
+	}
+}
+
+func TestClient_UpdatePublisher(t *testing.T) {
+	srv := fakePublisherProfileAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	desc := "Tools for everyone"
+	p, err := c.UpdatePublisher(context.Background(), "acme", &UpdatePublisherRequest{Description: &desc})
+	if err != nil {
+		t.Fatalf("UpdatePublisher() error: %v", err)
+	}
+	if p.Description != desc {
+		t.Fatalf("expected description %q, got %q", desc, p.Description)
+	}
+}
+
+func TestClient_CheckSlugAvailability(t *testing.T) {
+	srv := fakePublisherProfileAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	sa, err := c.CheckSlugAvailability(ctx, "fresh")
+	if err != nil || !sa.Available {
+		t.Fatalf("expected fresh to be available, got %+v, %v", sa, err)
+	}
+	sa, err = c.CheckSlugAvailability(ctx, "taken")
+	if err != nil || sa.Available || len(sa.Suggestions) != 1 {
+		t.Fatalf("expected taken to be unavailable with suggestions, got %+v, %v", sa, err)
+	}
+	sa, err = c.CheckSlugAvailability(ctx, "-bad-")
+	if err != nil || sa.Available || sa.Reason == "" {
+		t.Fatalf("expected invalid slug to be unavailable with reason, got %+v, %v", sa, err)
+	}
+}
+
+func TestClient_UploadPublisherLogo(t *testing.T) {
+	srv := fakePublisherProfileAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	p, err := c.UploadPublisherLogo(context.Background(), "acme", "logo.png", strings.NewReader("PNGDATA"))
+	if err != nil {
+		t.Fatalf("UploadPublisherLogo() error: %v", err)
+	}
+	if p.Logo != "logo.png:PNGDATA" {
+		t.Fatalf("unexpected logo: %s", p.Logo)
+	}
+}
+
+func TestClient_UploadPublisherLogo_rejected(t *testing.T) {
+	c := NewClient(WithBaseURL("http://127.0.0.1:1"))
+	ctx := context.Background()
+
+	if _, err := c.UploadPublisherLogo(ctx, "acme", "logo.gif", strings.NewReader("x")); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected unsupported type to be rejected, got %v", err)
+	}
+	big := strings.NewReader(strings.Repeat("x", maxLogoSize+1))
+	if _, err := c.UploadPublisherLogo(ctx, "acme", "logo.png", big); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected oversized logo to be rejected, got %v", err)
+	}
+}
+
+func TestClient_publisherProfile_serverDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "forbidden"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("tok"))
+	ctx := context.Background()
+
+	desc := "Tools for everyone"
+	checks := map[string]error{}
+	_, checks["update"] = c.UpdatePublisher(ctx, "omniview", &UpdatePublisherRequest{Description: &desc})
+	_, checks["logo"] = c.UploadPublisherLogo(ctx, "omniview", "logo.png", strings.NewReader("png"))
+	for name, err := range checks {
+		var permErr *PermissionError
+		if !errors.As(err, &permErr) || permErr.Permission != PermissionManageMembers {
+			t.Errorf("%s: expected PermissionError for manage_members, got %v", name, err)
+		}
+	}
+}
+
+func TestValidatePublisherSlug(t *testing.T) {
+	valid := []string{"abc", "omniview", "my-org-2"}
+	invalid := []string{"ab", "-abc", "abc-", "a--b", "ABC", "a_b", strings.Repeat("a", maxSlugLength+1)}
+	for _, s := range valid {
+		if err := ValidatePublisherSlug(s); err != nil {
+			t.Errorf("ValidatePublisherSlug(%q) unexpected error: %v", s, err)
+		}
+	}
+	for _, s := range invalid {
+		if err := ValidatePublisherSlug(s); err == nil {
+			t.Errorf("ValidatePublisherSlug(%q) expected error", s)
+		}
+	}
+}
+
+func TestValidateWebsite(t *testing.T) {
+	for _, w := range []string{"https://example.com", "http://example.com/path"} {
+		if err := ValidateWebsite(w); err != nil {
+			t.Errorf("ValidateWebsite(%q) unexpected error: %v", w, err)
+		}
+	}
+	for _, w := range []string{"example.com", "ftp://example.com", "https://", "::"} {
+		if err := ValidateWebsite(w); err == nil {
+			t.Errorf("ValidateWebsite(%q) expected error", w)
+		}
+	}
+}
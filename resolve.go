@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+)
+
+// versionsPageSize is the page size used when walking every version of a plugin.
+const versionsPageSize = 100
+
+// ResolveVersion returns the version of pluginID to install. A version pinned
+// in the client's lockfile is returned as-is, even if yanked. Otherwise the
+// highest visible, non-yanked version on the client's channel is chosen.
+// Prerelease versions are skipped unless the channel allows them, as are
+// versions whose staged rollout does not include the client. If every
+// candidate was skipped because it is yanked, the error wraps a
+// *YankedVersionError for the highest of them.
+func (c *Client) ResolveVersion(ctx context.Context, pluginID string) (*PluginVersion, error) {
+	if pinned, ok := c.lockfile.Pinned(pluginID); ok {
+		return c.GetVersion(ctx, pluginID, pinned)
+	}
+
+	versions, err := c.listAllVersions(ctx, pluginID)
+	if err != nil {
+		return nil, err
+	}
+	best := c.latestEligible(pluginID, versions)
+	if best == nil {
+		if y := c.latestMatching(pluginID, versions, c.offered); y != nil {
+			yankErr := &YankedVersionError{PluginID: pluginID, Version: y.Version, Reason: y.YankReason}
+			return nil, fmt.Errorf("%w: plugin %q: %w", ErrNoEligibleVersion, pluginID, yankErr)
+		}
+		return nil, fmt.Errorf("%w: plugin %q", ErrNoEligibleVersion, pluginID)
+	}
+	return best, nil
+}
+
+// CheckForUpdate returns the newest eligible version of pluginID on the
+// client's channel that is newer than current, or nil if current is up to
+// date. Plugins pinned in the client's lockfile never report updates.
+func (c *Client) CheckForUpdate(ctx context.Context, pluginID, current string) (*PluginVersion, error) {
+	if _, ok := c.lockfile.Pinned(pluginID); ok {
+		return nil, nil
+	}
+	cur, err := parseSemver(current)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := c.listAllVersions(ctx, pluginID)
+	if err != nil {
+		return nil, err
+	}
+	best := c.latestEligible(pluginID, versions)
+	if best == nil {
+		return nil, nil
+	}
+	if bv, _ := parseSemver(best.Version); bv.compare(cur) <= 0 {
+		return nil, nil
+	}
+	return best, nil
+}
+
+// eligible reports whether version v of pluginID may be offered by
+// resolution and update checks.
+func (c *Client) eligible(pluginID string, v *PluginVersion, sv semver) bool {
+	return !v.Yanked && c.offered(pluginID, v, sv)
+}
+
+// offered reports whether v would be eligible if it were not yanked.
+func (c *Client) offered(pluginID string, v *PluginVersion, sv semver) bool {
+	if !v.Visible {
+		return false
+	}
+	ch := c.Channel()
+	if !ch.Includes(v.Channel) {
+		return false
+	}
+	if sv.isPrerelease() && !ch.AllowsPrerelease() {
+		return false
+	}
+	return c.inRollout(pluginID, v)
+}
+
+// latestEligible returns the highest eligible version, skipping versions
+// that are not valid semver.
+func (c *Client) latestEligible(pluginID string, versions []PluginVersion) *PluginVersion {
+	return c.latestMatching(pluginID, versions, c.eligible)
+}
+
+// latestMatching returns the highest version accepted by match, skipping
+// versions that are not valid semver.
+func (c *Client) latestMatching(pluginID string, versions []PluginVersion, match func(string, *PluginVersion, semver) bool) *PluginVersion {
+	var (
+		best   *PluginVersion
+		bestSV semver
+	)
+	for i := range versions {
+		v := &versions[i]
+		sv, err := parseSemver(v.Version)
+		if err != nil || !match(pluginID, v, sv) {
+			continue
+		}
+		if best == nil || sv.compare(bestSV) > 0 {
+			best, bestSV = v, sv
+		}
+	}
+	return best
+}
+
+// listAllVersions walks every page of a plugin's versions.
+func (c *Client) listAllVersions(ctx context.Context, pluginID string) ([]PluginVersion, error) {
+	var all []PluginVersion
+	for page := 1; ; page++ {
+		res, err := c.ListVersions(ctx, pluginID, &ListOptions{Page: page, PerPage: versionsPageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, res.Items...)
+		if res.Pagination == nil || int32(page) >= res.Pagination.TotalPages || len(res.Items) == 0 {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeVersionsAPI serves the given versions of test-plugin for listing and
+// lookup, and records yank and visibility changes made through the API.
+// Listed versions leave out plugin_id, as list responses may.
+func fakeVersionsAPI(t *testing.T, versions []map[string]interface{}) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	byVersion := map[string]map[string]interface{}{}
+	for _, v := range versions {
+		v["plugin_id"] = "test-plugin"
+		byVersion[v["version"].(string)] = v
+	}
+
+	mux.HandleFunc("/v1/plugins/test-plugin/versions", func(w http.ResponseWriter, r *http.Request) {
+		listed := make([]map[string]interface{}, len(versions))
+		for i, v := range versions {
+			listed[i] = maps.Clone(v)
+			delete(listed[i], "plugin_id")
+		}
+		writeJSON(w, map[string]interface{}{
+			"success":    true,
+			"data":       listed,
+			"pagination": map[string]interface{}{"page": 1, "per_page": 100, "total": len(versions), "total_pages": 1},
+		})
+	})
+
+	mux.HandleFunc("/v1/plugins/test-plugin/versions/", func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.TrimPrefix(r.URL.Path, "/v1/plugins/test-plugin/versions/")
+		version, action, _ := strings.Cut(rest, "/")
+		v, ok := byVersion[version]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, map[string]interface{}{"success": false, "message": "version not found"})
+			return
+		}
+		switch {
+		case action == "yank" && r.Method == http.MethodPost:
+			var body YankVersionRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			v["yanked"], v["yank_reason"] = true, body.Reason
+		case action == "yank" && r.Method == http.MethodDelete:
+			v["yanked"], v["yank_reason"] = false, ""
+		case action == "" && r.Method == http.MethodPatch:
+			var body VersionVisibilityRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			v["visible"] = body.Visible
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": v})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func testVersions() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"version": "1.0.0", "visible": true},
+		{"version": "1.1.0", "visible": true},
+		{"version": "1.2.0", "visible": true, "yanked": true, "yank_reason": "crashes on startup"},
+		{"version": "1.3.0", "visible": false},
+	}
+}
+
+func TestClient_ResolveVersion_skipsYankedAndHidden(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	v, err := c.ResolveVersion(context.Background(), "test-plugin")
+	if err != nil {
+		t.Fatalf("ResolveVersion() error: %v", err)
+	}
+	if v.Version != "1.1.0" {
+		t.Fatalf("expected 1.1.0, got %s", v.Version)
+	}
+}
+
+func TestClient_ResolveVersion_pinnedYanked(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	lf := &Lockfile{}
+	lf.Pin("test-plugin", "1.2.0", "")
+	c := NewClient(WithBaseURL(srv.URL), WithLockfile(lf))
+
+	v, err := c.ResolveVersion(context.Background(), "test-plugin")
+	if err != nil {
+		t.Fatalf("ResolveVersion() error: %v", err)
+	}
+	if v.Version != "1.2.0" || !v.Yanked {
+		t.Fatalf("expected pinned yanked 1.2.0, got %+v", v)
+	}
+}
+
+func TestClient_ResolveVersion_noneEligible(t *testing.T) {
+	srv := fakeVersionsAPI(t, []map[string]interface{}{{"version": "1.0.0", "visible": true, "yanked": true}})
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if _, err := c.ResolveVersion(context.Background(), "test-plugin"); !errors.Is(err, ErrNoEligibleVersion) {
+		t.Fatalf("expected ErrNoEligibleVersion, got %v", err)
+	}
+}
+
+func TestClient_ResolveVersion_onlyYanked(t *testing.T) {
+	srv := fakeVersionsAPI(t, []map[string]interface{}{
+		{"version": "1.0.0", "visible": true, "yanked": true, "yank_reason": "old"},
+		{"version": "1.1.0", "visible": true, "yanked": true, "yank_reason": "crashes on startup"},
+		{"version": "2.0.0", "visible": false},
+	})
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	_, err := c.ResolveVersion(context.Background(), "test-plugin")
+	var yankErr *YankedVersionError
+	if !errors.Is(err, ErrNoEligibleVersion) || !errors.As(err, &yankErr) {
+		t.Fatalf("expected ErrNoEligibleVersion wrapping YankedVersionError, got %v", err)
+	}
+	if yankErr.Version != "1.1.0" || yankErr.Reason != "crashes on startup" {
+		t.Fatalf("expected highest yanked version and reason, got %+v", yankErr)
+	}
+}
+
+func TestClient_CheckForUpdate(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	v, err := c.CheckForUpdate(ctx, "test-plugin", "1.0.0")
+	if err != nil {
+		t.Fatalf("CheckForUpdate() error: %v", err)
+	}
+	if v == nil || v.Version != "1.1.0" {
+		t.Fatalf("expected update to 1.1.0, got %+v", v)
+	}
+
+	v, err = c.CheckForUpdate(ctx, "test-plugin", "1.1.0")
+	if err != nil {
+		t.Fatalf("CheckForUpdate() error: %v", err)
+	}
+	if v != nil {
+		t.Fatalf("expected no update, got %s", v.Version)
+	}
+}
+
+func TestClient_DownloadPlugin_refusesYanked(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	_, err := c.DownloadPlugin(context.Background(), "test-plugin", "1.2.0")
+
+	var yankErr *YankedVersionError
+	if !errors.As(err, &yankErr) {
+		t.Fatalf("expected YankedVersionError, got %v", err)
+	}
+	if yankErr.Reason != "crashes on startup" {
+		t.Fatalf("expected yank reason in error, got %q", yankErr.Reason)
+	}
+	if !strings.Contains(err.Error(), "crashes on startup") {
+		t.Fatalf("expected reason in message: %v", err)
+	}
+	if !errors.Is(err, ErrVersionYanked) {
+		t.Fatal("expected errors.Is ErrVersionYanked")
+	}
+}
+
+func TestClient_DownloadPlugin_refusesHidden(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	if _, err := c.DownloadPlugin(context.Background(), "test-plugin", "1.3.0"); !errors.Is(err, ErrVersionHidden) {
+		t.Fatalf("expected ErrVersionHidden, got %v", err)
+	}
+
+	lf := &Lockfile{}
+	lf.Pin("test-plugin", "1.3.0", "")
+	c = NewClient(WithBaseURL(srv.URL), WithLockfile(lf))
+	if _, err := c.DownloadPlugin(context.Background(), "test-plugin", "1.3.0"); errors.Is(err, ErrVersionHidden) {
+		t.Fatalf("pinned hidden version should not be refused, got %v", err)
+	}
+}
+
+func TestClient_DownloadPlugin_pinnedChecksumMismatch(t *testing.T) {
+	srv := fakeVersionsAPI(t, []map[string]interface{}{{
+		"version":   "1.0.0",
+		"visible":   true,
+		"artifacts": map[string]interface{}{CurrentPlatform(): map[string]interface{}{"checksum": "replaced"}},
+	}})
+	defer srv.Close()
+
+	lf := &Lockfile{}
+	lf.Pin("test-plugin", "1.0.0", "original")
+	c := NewClient(WithBaseURL(srv.URL), WithLockfile(lf))
+	_, err := c.DownloadPlugin(context.Background(), "test-plugin", "1.0.0")
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func TestLockfile_roundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "omniview.lock")
+
+	lf, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatalf("ReadLockfile() on missing file error: %v", err)
+	}
+	if _, ok := lf.Pinned("test-plugin"); ok {
+		t.Fatal("expected empty lockfile")
+	}
+
+	lf.Pin("test-plugin", "1.2.0", "abc123")
+	if err := WriteLockfile(path, lf); err != nil {
+		t.Fatalf("WriteLockfile() error: %v", err)
+	}
+	got, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatalf("ReadLockfile() error: %v", err)
+	}
+	if v, ok := got.Pinned("test-plugin"); !ok || v != "1.2.0" {
+		t.Fatalf("expected pinned 1.2.0, got %q, %v", v, ok)
+	}
+}
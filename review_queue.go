@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RegistryRole is a registry-wide moderation role, distinct from the
+// per-publisher PublisherRole.
+type RegistryRole string
+
+const (
+	// RegistryReviewer may work the review queue.
+	RegistryReviewer RegistryRole = "reviewer"
+	// RegistryAdmin may additionally reassign submissions between reviewers.
+	RegistryAdmin RegistryRole = "admin"
+)
+
+// Token scopes that grant the reviewer roles.
+const (
+	ScopeReview = "submissions:review"
+	ScopeAdmin  = "admin"
+)
+
+// ReviewQueueOptions filters the global review queue.
+type ReviewQueueOptions struct {
+	ListOptions
+	PublisherSlug string
+	// ReviewerID limits the queue to submissions claimed by a reviewer.
+	ReviewerID *uint
+	// Unassigned limits the queue to submissions no reviewer has claimed.
+	Unassigned bool
+}
+
+func (o *ReviewQueueOptions) buildQuery() string {
+	if o == nil {
+		return ""
+	}
+	q, _ := url.ParseQuery(strings.TrimPrefix(o.ListOptions.buildQuery(), "?"))
+	if o.PublisherSlug != "" {
+		q.Set("publisher", o.PublisherSlug)
+	}
+	if o.ReviewerID != nil {
+		q.Set("reviewer_id", strconv.FormatUint(uint64(*o.ReviewerID), 10))
+	}
+	if o.Unassigned {
+		q.Set("unassigned", "true")
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// ReviewDecisionRequest is the request body for approving, rejecting or
+// requesting changes to a submission.
+type ReviewDecisionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// ReassignSubmissionRequest is the request body for reassigning a submission.
+type ReassignSubmissionRequest struct {
+	ReviewerID uint `json:"reviewer_id"`
+}
+
+// ListReviewQueue returns submissions awaiting review across all publishers.
+func (c *Client) ListReviewQueue(ctx context.Context, opts *ReviewQueueOptions) (ListResult[Submission], error) {
+	if err := c.requireRole(ctx, RegistryReviewer, "list review queue"); err != nil {
+		return ListResult[Submission]{}, err
+	}
+	var items []Submission
+	pg, err := c.getList(ctx, "/v1/admin/submissions"+opts.buildQuery(), &items)
+	if err != nil {
+		return ListResult[Submission]{}, asRoleError(err, RegistryReviewer, "list review queue")
+	}
+	if items == nil {
+		items = []Submission{}
+	}
+	return ListResult[Submission]{Items: items, Pagination: pg}, nil
+}
+
+// ClaimSubmission assigns a submission to the caller for review.
+func (c *Client) ClaimSubmission(ctx context.Context, id string) (*Submission, error) {
+	return c.reviewAction(ctx, id, "claim", RegistryReviewer, nil)
+}
+
+// ApproveSubmission approves a submission for publication.
+func (c *Client) ApproveSubmission(ctx context.Context, id, notes string) (*Submission, error) {
+	return c.reviewAction(ctx, id, "approve", RegistryReviewer, &ReviewDecisionRequest{Notes: notes})
+}
+
+// RejectSubmission rejects a submission. notes are shown to the publisher and
+// are required.
+func (c *Client) RejectSubmission(ctx context.Context, id, notes string) (*Submission, error) {
+	if strings.TrimSpace(notes) == "" {
+		return nil, &FieldError{Field: "notes", Message: "are required when rejecting"}
+	}
+	return c.reviewAction(ctx, id, "reject", RegistryReviewer, &ReviewDecisionRequest{Notes: notes})
+}
+
+// RequestChanges returns a submission to the publisher with notes describing
+// the changes needed. notes are required.
+func (c *Client) RequestChanges(ctx context.Context, id, notes string) (*Submission, error) {
+	if strings.TrimSpace(notes) == "" {
+		return nil, &FieldError{Field: "notes", Message: "are required when requesting changes"}
+	}
+	return c.reviewAction(ctx, id, "request-changes", RegistryReviewer, &ReviewDecisionRequest{Notes: notes})
+}
+
+// ReassignSubmission moves a claimed submission to another reviewer. It
+// requires the admin role.
+func (c *Client) ReassignSubmission(ctx context.Context, id string, reviewerID uint) (*Submission, error) {
+	return c.reviewAction(ctx, id, "reassign", RegistryAdmin, &ReassignSubmissionRequest{ReviewerID: reviewerID})
+}
+
+func (c *Client) reviewAction(ctx context.Context, id, action string, role RegistryRole, body interface{}) (*Submission, error) {
+	if err := c.requireRole(ctx, role, action); err != nil {
+		return nil, err
+	}
+	var sub Submission
+	path := fmt.Sprintf("/v1/admin/submissions/%s/%s", id, action)
+	if err := c.post(ctx, path, body, &sub); err != nil {
+		return nil, asRoleError(err, role, action)
+	}
+	return &sub, nil
+}
+
+// requireRole fails fast when the caller's token is known to lack role. The
+// check is skipped for API keys and for tokens that carry no scopes, in which
+// case the server decides.
+func (c *Client) requireRole(ctx context.Context, role RegistryRole, action string) error {
+	co := callOptionsFrom(ctx)
+	if co.apiKey != "" {
+		return nil
+	}
+	var (
+		claims *TokenClaims
+		err    error
+	)
+	if co.token != "" {
+		claims, err = ParseTokenClaims(co.token)
+	} else {
+		if apiKey, _, _ := c.credentials(); apiKey != "" {
+			return nil
+		}
+		claims, err = c.TokenClaims(ctx)
+	}
+	if err != nil || len(claims.Scopes) == 0 || claims.HasScope(ScopeAdmin) {
+		return nil
+	}
+	if role == RegistryReviewer && claims.HasScope(ScopeReview) {
+		return nil
+	}
+	return &RoleError{Required: role, Action: action}
+}
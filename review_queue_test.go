@@ -0,0 +1,147 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fakeReviewAPI(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var calls []string
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/admin/submissions", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "list?"+r.URL.RawQuery)
+		writeJSON(w, map[string]interface{}{
+			"success":    true,
+			"data":       []map[string]interface{}{{"id": "sub-1", "status": "pending_review"}},
+			"pagination": map[string]interface{}{"page": 1, "per_page": 20, "total": 1, "total_pages": 1},
+		})
+	})
+	mux.HandleFunc("/v1/admin/submissions/sub-1/", func(w http.ResponseWriter, r *http.Request) {
+		action := strings.TrimPrefix(r.URL.Path, "/v1/admin/submissions/sub-1/")
+		calls = append(calls, action)
+		if r.Header.Get("X-API-Key") == "publisher-key" {
+			w.WriteHeader(http.StatusForbidden)
+			writeJSON(w, map[string]interface{}{"success": false, "message": "reviewer role required"})
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sub := map[string]interface{}{"id": "sub-1", "status": "pending_review", "reviewer_id": 7}
+		switch action {
+		case "approve":
+			sub["status"], sub["reviewer_notes"] = "approved", body["notes"]
+		case "reject":
+			sub["status"], sub["reviewer_notes"] = "rejected", body["notes"]
+		case "request-changes":
+			sub["status"], sub["reviewer_notes"] = "changes_requested", body["notes"]
+		case "reassign":
+			sub["reviewer_id"] = body["reviewer_id"]
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": sub})
+	})
+	return httptest.NewServer(mux), &calls
+}
+
+func TestClient_ReviewQueue(t *testing.T) {
+	srv, calls := fakeReviewAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("reviewer-key"))
+	ctx := context.Background()
+
+	reviewer := uint(7)
+	res, err := c.ListReviewQueue(ctx, &ReviewQueueOptions{
+		ListOptions:   ListOptions{Status: string(SubmissionPendingReview), PerPage: 20},
+		PublisherSlug: "acme",
+		ReviewerID:    &reviewer,
+	})
+	if err != nil {
+		t.Fatalf("ListReviewQueue() error: %v", err)
+	}
+	if len(res.Items) != 1 {
+		t.Fatalf("expected 1 submission, got %d", len(res.Items))
+	}
+	if want := "list?per_page=20&publisher=acme&reviewer_id=7&status=pending_review"; (*calls)[0] != want {
+		t.Fatalf("expected query %q, got %q", want, (*calls)[0])
+	}
+
+	sub, err := c.ClaimSubmission(ctx, "sub-1")
+	if err != nil || sub.ReviewerID == nil || *sub.ReviewerID != 7 {
+		t.Fatalf("ClaimSubmission() = %+v, %v", sub, err)
+	}
+	sub, err = c.ApproveSubmission(ctx, "sub-1", "looks good")
+	if err != nil || sub.Status != SubmissionApproved || sub.ReviewerNotes != "looks good" {
+		t.Fatalf("ApproveSubmission() = %+v, %v", sub, err)
+	}
+	sub, err = c.RequestChanges(ctx, "sub-1", "add an icon")
+	if err != nil || sub.Status != SubmissionChangesRequested {
+		t.Fatalf("RequestChanges() = %+v, %v", sub, err)
+	}
+	sub, err = c.RejectSubmission(ctx, "sub-1", "malware")
+	if err != nil || sub.Status != SubmissionRejected {
+		t.Fatalf("RejectSubmission() = %+v, %v", sub, err)
+	}
+	sub, err = c.ReassignSubmission(ctx, "sub-1", 9)
+	if err != nil || *sub.ReviewerID != 9 {
+		t.Fatalf("ReassignSubmission() = %+v, %v", sub, err)
+	}
+
+	if _, err := c.RejectSubmission(ctx, "sub-1", " "); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for empty notes, got %v", err)
+	}
+	if len(*calls) != 6 {
+		t.Fatalf("expected 6 calls, got %v", *calls)
+	}
+}
+
+func TestClient_ReviewQueue_forbidden(t *testing.T) {
+	srv, _ := fakeReviewAPI(t)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("publisher-key"))
+	_, err := c.ApproveSubmission(context.Background(), "sub-1", "")
+
+	var roleErr *RoleError
+	if !errors.As(err, &roleErr) || roleErr.Required != RegistryReviewer || roleErr.Action != "approve" {
+		t.Fatalf("expected RoleError, got %v", err)
+	}
+	if !errors.Is(err, ErrNotReviewer) || !errors.Is(err, ErrPermissionDenied) || !IsForbidden(err) {
+		t.Fatalf("expected role error to match sentinels, got %v", err)
+	}
+}
+
+func TestClient_ReviewQueue_scopeFailFast(t *testing.T) {
+	srv, calls := fakeReviewAPI(t)
+	defer srv.Close()
+
+	exp := time.Now().Add(time.Hour).Unix()
+	publisher := makeJWT(t, map[string]interface{}{"sub": "u1", "exp": exp, "scope": "plugins:publish"})
+	reviewer := makeJWT(t, map[string]interface{}{"sub": "u2", "exp": exp, "scope": "plugins:publish " + ScopeReview})
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken(publisher))
+	ctx := context.Background()
+
+	if _, err := c.ClaimSubmission(ctx, "sub-1"); !errors.Is(err, ErrNotReviewer) {
+		t.Fatalf("expected ErrNotReviewer, got %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Fatal("fail-fast role check should not reach the server")
+	}
+
+	rctx := WithCallOptions(ctx, CallToken(reviewer))
+	if _, err := c.ClaimSubmission(rctx, "sub-1"); err != nil {
+		t.Fatalf("ClaimSubmission() with reviewer token error: %v", err)
+	}
+	var roleErr *RoleError
+	if _, err := c.ReassignSubmission(rctx, "sub-1", 9); !errors.As(err, &roleErr) || roleErr.Required != RegistryAdmin {
+		t.Fatalf("expected admin RoleError, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRolloutBucket_deterministic(t *testing.T) {
+	a := RolloutBucket("install-1", "test-plugin", "1.1.0")
+	if b := RolloutBucket("install-1", "test-plugin", "1.1.0"); a != b {
+		t.Fatalf("bucket not stable: %d vs %d", a, b)
+	}
+	if a < 0 || a >= 100 {
+		t.Fatalf("bucket out of range: %d", a)
+	}
+
+	// Over many installations, roughly the requested share is included.
+	r := &Rollout{State: RolloutActive, Percentage: 25}
+	in := 0
+	for i := 0; i < 10000; i++ {
+		if r.Includes(fmt.Sprintf("install-%d", i), "test-plugin", "1.1.0", nil) {
+			in++
+		}
+	}
+	if in < 2200 || in > 2800 {
+		t.Fatalf("expected ~2500 of 10000 included, got %d", in)
+	}
+}
+
+func TestRollout_Includes(t *testing.T) {
+	var nilRollout *Rollout
+	if !nilRollout.Includes("", "p", "1.0.0", nil) {
+		t.Error("nil rollout should include everyone")
+	}
+	if (&Rollout{State: RolloutHalted, Percentage: 100}).Includes("id", "p", "1.0.0", nil) {
+		t.Error("halted rollout should include no one")
+	}
+	if !(&Rollout{State: RolloutComplete}).Includes("", "p", "1.0.0", nil) {
+		t.Error("complete rollout should include everyone")
+	}
+	cohort := &Rollout{State: RolloutPaused, Percentage: 0, Cohorts: []string{"internal"}}
+	if !cohort.Includes("", "p", "1.0.0", []string{"internal"}) {
+		t.Error("cohort member should be included")
+	}
+	if cohort.Includes("id", "p", "1.0.0", []string{"external"}) {
+		t.Error("non-member should be excluded at 0%")
+	}
+	if (&Rollout{State: RolloutActive, Percentage: 99}).Includes("", "p", "1.0.0", nil) {
+		t.Error("empty installation ID should be excluded below 100%")
+	}
+}
+
+// installationBucketed returns an installation ID whose bucket for
+// test-plugin@version satisfies want.
+func installationBucketed(t *testing.T, version string, want func(id string, bucket int) bool) string {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprintf("install-%d", i)
+		if want(id, RolloutBucket(id, "test-plugin", version)) {
+			return id
+		}
+	}
+	t.Fatal("no installation ID found")
+	return ""
+}
+
+func TestClient_ResolveVersion_rollout(t *testing.T) {
+	versions := func() []map[string]interface{} {
+		return []map[string]interface{}{
+			{"version": "1.0.0", "visible": true},
+			{"version": "1.1.0", "visible": true, "rollout": map[string]interface{}{"state": "active", "percentage": 10}},
+		}
+	}
+	// The buckets must come from the requested plugin ID, which the listed
+	// versions leave out; pick IDs that would land the other way without it.
+	inside := installationBucketed(t, "1.1.0", func(id string, b int) bool {
+		return b < 10 && RolloutBucket(id, "", "1.1.0") >= 10
+	})
+	outside := installationBucketed(t, "1.1.0", func(id string, b int) bool {
+		return b >= 10 && RolloutBucket(id, "", "1.1.0") < 10
+	})
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"inside", []Option{WithInstallationID(inside)}, "1.1.0"},
+		{"outside", []Option{WithInstallationID(outside)}, "1.0.0"},
+		{"anonymous", nil, "1.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeVersionsAPI(t, versions())
+			defer srv.Close()
+
+			c := NewClient(append([]Option{WithBaseURL(srv.URL)}, tt.opts...)...)
+			v, err := c.ResolveVersion(context.Background(), "test-plugin")
+			if err != nil {
+				t.Fatalf("ResolveVersion() error: %v", err)
+			}
+			if v.Version != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, v.Version)
+			}
+		})
+	}
+}
+
+func TestClient_RolloutActions(t *testing.T) {
+	mux := http.NewServeMux()
+	var calls []string
+	mux.HandleFunc("/v1/plugins/test-plugin/versions/1.1.0/rollout", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" rollout")
+		var body RolloutRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{
+			"version": "1.1.0",
+			"rollout": map[string]interface{}{"state": "active", "percentage": body.Percentage, "cohorts": body.Cohorts},
+		}})
+	})
+	for _, action := range []string{"advance", "pause", "resume", "halt"} {
+		action := action
+		mux.HandleFunc("/v1/plugins/test-plugin/versions/1.1.0/rollout/"+action, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("%s: expected POST, got %s", action, r.Method)
+			}
+			calls = append(calls, action)
+			writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{
+				"version": "1.1.0",
+				"rollout": map[string]interface{}{"state": "active", "percentage": 50},
+			}})
+		})
+	}
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("key"))
+	ctx := context.Background()
+
+	v, err := c.SetRollout(ctx, "test-plugin", "1.1.0", &RolloutRequest{Percentage: 5, Cohorts: []string{"internal"}})
+	if err != nil {
+		t.Fatalf("SetRollout() error: %v", err)
+	}
+	if v.Rollout == nil || v.Rollout.Percentage != 5 || len(v.Rollout.Cohorts) != 1 {
+		t.Fatalf("unexpected rollout: %+v", v.Rollout)
+	}
+	if _, err := c.AdvanceRollout(ctx, "test-plugin", "1.1.0", 50); err != nil {
+		t.Fatalf("AdvanceRollout() error: %v", err)
+	}
+	if _, err := c.PauseRollout(ctx, "test-plugin", "1.1.0"); err != nil {
+		t.Fatalf("PauseRollout() error: %v", err)
+	}
+	if _, err := c.ResumeRollout(ctx, "test-plugin", "1.1.0"); err != nil {
+		t.Fatalf("ResumeRollout() error: %v", err)
+	}
+	if _, err := c.HaltRollout(ctx, "test-plugin", "1.1.0", "regression"); err != nil {
+		t.Fatalf("HaltRollout() error: %v", err)
+	}
+	if len(calls) != 5 {
+		t.Fatalf("expected 5 calls, got %v", calls)
+	}
+
+	if _, err := c.AdvanceRollout(ctx, "test-plugin", "1.1.0", 150); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	if len(calls) != 5 {
+		t.Fatal("invalid percentage should not reach the server")
+	}
+}
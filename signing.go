@@ -0,0 +1,181 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+const (
+	signingKeyFileVersion = 1
+	signingKeyAD          = "omniview-signing-key-v1"
+
+	pemPrivateKeyType = "PRIVATE KEY"
+	pemPublicKeyType  = "PUBLIC KEY"
+)
+
+// GenerateSigningKey returns a new Ed25519 key pair for signing artifacts.
+func GenerateSigningKey() (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating signing key: %w", err)
+	}
+	return pub, priv, nil
+}
+
+// PublicKeyHex encodes pub in the hex form accepted by SetPublicKey.
+func PublicKeyHex(pub ed25519.PublicKey) string {
+	return hex.EncodeToString(pub)
+}
+
+// ParsePublicKeyHex decodes a hex-encoded Ed25519 public key.
+func ParsePublicKeyHex(hexKey string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key hex: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key length: got %d bytes, want %d", len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// MarshalPrivateKeyPEM encodes priv as an unencrypted PKCS#8 PEM block.
+func MarshalPrivateKeyPEM(priv ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, fmt.Errorf("encoding private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKeyType, Bytes: der}), nil
+}
+
+// ParsePrivateKeyPEM decodes a PKCS#8 PEM block holding an Ed25519 private key.
+func ParsePrivateKeyPEM(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemPrivateKeyType {
+		return nil, fmt.Errorf("no %s PEM block found", pemPrivateKeyType)
+	}
+	return parsePKCS8Ed25519(block.Bytes)
+}
+
+// MarshalPublicKeyPEM encodes pub as a PKIX PEM block.
+func MarshalPublicKeyPEM(pub ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("encoding public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKeyType, Bytes: der}), nil
+}
+
+// ParsePublicKeyPEM decodes a PKIX PEM block holding an Ed25519 public key.
+func ParsePublicKeyPEM(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemPublicKeyType {
+		return nil, fmt.Errorf("no %s PEM block found", pemPublicKeyType)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("decoding public key: %w", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is %T, not Ed25519", key)
+	}
+	return pub, nil
+}
+
+func parsePKCS8Ed25519(der []byte) (ed25519.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("decoding private key: %w", err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not Ed25519", key)
+	}
+	return priv, nil
+}
+
+// SignChecksum signs an artifact checksum and returns the base64 signature
+// stored in Artifact.Signature.
+func SignChecksum(priv ed25519.PrivateKey, checksum string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(checksum)))
+}
+
+// SignArtifact sets a.Signature to the signature of a.Checksum and a.KeyID
+// to keyID, or to the key's default ID if keyID is empty.
+func SignArtifact(priv ed25519.PrivateKey, keyID string, a *Artifact) error {
+	if a.Checksum == "" {
+		return &FieldError{Field: "checksum", Message: "is required"}
+	}
+	if keyID == "" {
+		keyID = KeyID(priv.Public().(ed25519.PublicKey))
+	}
+	a.Signature = SignChecksum(priv, a.Checksum)
+	a.KeyID = keyID
+	return nil
+}
+
+// signingKeyFile is the on-disk layout of an encrypted signing key.
+type signingKeyFile struct {
+	Version int `json:"version"`
+	sealedBox
+}
+
+// WriteEncryptedSigningKey encrypts priv under mk and writes it to path with
+// mode 0600. The key is stored as PKCS#8 sealed with AES-256-GCM under a
+// scrypt-derived key, in the same format as EncryptedFileStore.
+func WriteEncryptedSigningKey(path string, priv ed25519.PrivateKey, mk MasterKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return fmt.Errorf("encoding private key: %w", err)
+	}
+	kdf, err := newKDFParams()
+	if err != nil {
+		return err
+	}
+	key, err := deriveKey(mk.secret, kdf)
+	if err != nil {
+		return err
+	}
+	box, err := seal(key, kdf, der, []byte(signingKeyAD))
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(signingKeyFile{Version: signingKeyFileVersion, sealedBox: *box}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0o600)
+}
+
+// LoadEncryptedSigningKey reads a signing key written by
+// WriteEncryptedSigningKey. A wrong key returns ErrDecryptFailed.
+func LoadEncryptedSigningKey(path string, mk MasterKey) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+	var f signingKeyFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decoding signing key file: %w", err)
+	}
+	if f.Version != signingKeyFileVersion {
+		return nil, fmt.Errorf("unsupported signing key file version %d", f.Version)
+	}
+	key, err := deriveKey(mk.secret, f.KDF)
+	if err != nil {
+		return nil, err
+	}
+	der, err := open(key, &f.sealedBox, []byte(signingKeyAD))
+	if err != nil {
+		return nil, err
+	}
+	return parsePKCS8Ed25519(der)
+}
@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PublisherSigningKey is an Ed25519 public key a publisher has registered for
+// co-signing its artifacts.
+type PublisherSigningKey struct {
+	// ID is the key ID carried in ArtifactSignature.KeyID.
+	ID          string     `json:"id"`
+	PublisherID string     `json:"publisher_id"`
+	Name        string     `json:"name"`
+	PublicKey   string     `json:"public_key"` // hex-encoded
+	CreatedByID uint       `json:"created_by_id"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TrustedKey converts k for use in a TrustStore.
+func (k *PublisherSigningKey) TrustedKey() (TrustedKey, error) {
+	pub, err := ParsePublicKeyHex(k.PublicKey)
+	if err != nil {
+		return TrustedKey{}, fmt.Errorf("signing key %s: %w", k.ID, err)
+	}
+	tk := TrustedKey{ID: k.ID, PublicKey: pub, Revoked: k.RevokedAt != nil}
+	if k.ExpiresAt != nil {
+		tk.NotAfter = *k.ExpiresAt
+	}
+	return tk, nil
+}
+
+// RegisterSigningKeyRequest is the request body for registering a publisher
+// signing key.
+type RegisterSigningKeyRequest struct {
+	Name      string     `json:"name"`
+	PublicKey string     `json:"public_key"` // hex-encoded, as returned by PublicKeyHex
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Validate checks the request before it is sent.
+func (r *RegisterSigningKeyRequest) Validate() error {
+	var errs []error
+	if r.Name == "" {
+		errs = append(errs, &FieldError{Field: "name", Message: "is required"})
+	}
+	if _, err := ParsePublicKeyHex(r.PublicKey); err != nil {
+		errs = append(errs, &FieldError{Field: "public_key", Message: "must be a hex-encoded Ed25519 public key"})
+	}
+	return errors.Join(errs...)
+}
+
+// ListSigningKeys returns the signing keys registered by a publisher,
+// including revoked and expired ones.
+func (c *Client) ListSigningKeys(ctx context.Context, publisherSlug string) ([]PublisherSigningKey, error) {
+	var keys []PublisherSigningKey
+	path := fmt.Sprintf("/v1/publishers/%s/signing-keys", publisherSlug)
+	if err := c.get(ctx, path, &keys); err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []PublisherSigningKey{}
+	}
+	return keys, nil
+}
+
+// RegisterSigningKey registers a public key the publisher will co-sign
+// artifacts with. Requires the publish permission.
+func (c *Client) RegisterSigningKey(ctx context.Context, publisherSlug string, req *RegisterSigningKeyRequest) (*PublisherSigningKey, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.requirePermission(ctx, publisherSlug, PermissionPublish); err != nil {
+		return nil, err
+	}
+	var key PublisherSigningKey
+	path := fmt.Sprintf("/v1/publishers/%s/signing-keys", publisherSlug)
+	if err := c.post(ctx, path, req, &key); err != nil {
+		return nil, asPermissionError(err, publisherSlug, "", PermissionPublish)
+	}
+	return &key, nil
+}
+
+// RevokeSigningKey revokes a publisher signing key. Artifacts co-signed only
+// with it stop satisfying policies that require a publisher signature.
+func (c *Client) RevokeSigningKey(ctx context.Context, publisherSlug, keyID string) error {
+	if err := c.requirePermission(ctx, publisherSlug, PermissionPublish); err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/v1/publishers/%s/signing-keys/%s", publisherSlug, keyID)
+	if err := c.del(ctx, path, nil); err != nil {
+		return asPermissionError(err, publisherSlug, "", PermissionPublish)
+	}
+	return nil
+}
+
+// PublisherTrustStore returns a TrustStore holding every signing key
+// registered by a publisher, with revoked and expired keys marked as such.
+func (c *Client) PublisherTrustStore(ctx context.Context, publisherSlug string) (*TrustStore, error) {
+	keys, err := c.ListSigningKeys(ctx, publisherSlug)
+	if err != nil {
+		return nil, err
+	}
+	trusted := make([]TrustedKey, 0, len(keys))
+	for i := range keys {
+		tk, err := keys[i].TrustedKey()
+		if err != nil {
+			return nil, err
+		}
+		trusted = append(trusted, tk)
+	}
+	return NewTrustStore(trusted...)
+}
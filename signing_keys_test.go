@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func fakeSigningKeysAPI(t *testing.T, keys []map[string]interface{}) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/publishers/omniview/signing-keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, map[string]interface{}{"success": true, "data": keys})
+		case http.MethodPost:
+			var body RegisterSigningKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, map[string]interface{}{
+				"success": true,
+				"data":    map[string]interface{}{"id": "sk-new", "name": body.Name, "public_key": body.PublicKey},
+			})
+		}
+	})
+	mux.HandleFunc("/v1/publishers/omniview/signing-keys/sk-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, map[string]interface{}{"success": true})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_RegisterSigningKey(t *testing.T) {
+	srv := fakeSigningKeysAPI(t, nil)
+	c := NewClient(WithBaseURL(srv.URL), WithToken("tok"))
+	pub, _ := newTestKey(t)
+
+	key, err := c.RegisterSigningKey(context.Background(), "omniview", &RegisterSigningKeyRequest{Name: "release", PublicKey: PublicKeyHex(pub)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != "sk-new" || key.PublicKey != PublicKeyHex(pub) {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+
+	_, err = c.RegisterSigningKey(context.Background(), "omniview", &RegisterSigningKeyRequest{PublicKey: "zz"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+
+	if err := c.RevokeSigningKey(context.Background(), "omniview", "sk-1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_PublisherTrustStore(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	freezeTime(t, now)
+
+	activePub, activePriv := newTestKey(t)
+	revokedPub, revokedPriv := newTestKey(t)
+	expiredPub, expiredPriv := newTestKey(t)
+	srv := fakeSigningKeysAPI(t, []map[string]interface{}{
+		{"id": "sk-1", "name": "active", "public_key": PublicKeyHex(activePub)},
+		{"id": "sk-2", "name": "revoked", "public_key": PublicKeyHex(revokedPub), "revoked_at": "2026-05-01T00:00:00Z"},
+		{"id": "sk-3", "name": "expired", "public_key": PublicKeyHex(expiredPub), "expires_at": "2026-05-01T00:00:00Z"},
+	})
+	c := NewClient(WithBaseURL(srv.URL))
+
+	s, err := c.PublisherTrustStore(context.Background(), "omniview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.Keys()); n != 3 {
+		t.Fatalf("got %d keys, want 3", n)
+	}
+
+	const checksum = "abc123"
+	if err := s.Verify(checksum, "sk-1", SignChecksum(activePriv, checksum)); err != nil {
+		t.Fatalf("active key: %v", err)
+	}
+	if err := s.Verify(checksum, "sk-2", SignChecksum(revokedPriv, checksum)); !errors.Is(err, ErrSigningKeyRevoked) {
+		t.Fatalf("expected ErrSigningKeyRevoked, got %v", err)
+	}
+	if err := s.Verify(checksum, "sk-3", SignChecksum(expiredPriv, checksum)); !errors.Is(err, ErrSigningKeyExpired) {
+		t.Fatalf("expected ErrSigningKeyExpired, got %v", err)
+	}
+}
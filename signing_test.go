@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSigningKey_hexRoundTrip(t *testing.T) {
+	pub, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ParsePublicKeyHex(PublicKeyHex(pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(pub) {
+		t.Fatal("hex round trip changed the public key")
+	}
+
+	original := OmniviewPublicKeyHex
+	t.Cleanup(func() { _ = SetPublicKey(original) })
+	if err := SetPublicKey(PublicKeyHex(pub)); err != nil {
+		t.Fatalf("SetPublicKey rejected generated key: %v", err)
+	}
+
+	checksum := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if err := VerifyArtifactSignature(checksum, SignChecksum(priv, checksum)); err != nil {
+		t.Fatalf("signature did not verify: %v", err)
+	}
+	if err := VerifyArtifactSignature("other", SignChecksum(priv, checksum)); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestSigningKey_pemRoundTrip(t *testing.T) {
+	pub, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privPEM, err := MarshalPrivateKeyPEM(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotPriv, err := ParsePrivateKeyPEM(privPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotPriv.Equal(priv) {
+		t.Fatal("PEM round trip changed the private key")
+	}
+
+	pubPEM, err := MarshalPublicKeyPEM(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotPub, err := ParsePublicKeyPEM(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotPub.Equal(pub) {
+		t.Fatal("PEM round trip changed the public key")
+	}
+
+	if _, err := ParsePrivateKeyPEM(pubPEM); err == nil {
+		t.Fatal("expected error parsing a public key as private")
+	}
+}
+
+func TestSignArtifact(t *testing.T) {
+	pub, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swapPublicKey(t, pub)
+
+	a := &Artifact{Checksum: "abc123"}
+	if err := SignArtifact(priv, "", a); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyArtifactSignature(a.Checksum, a.Signature); err != nil {
+		t.Fatalf("signed artifact did not verify: %v", err)
+	}
+	if a.KeyID != KeyID(pub) {
+		t.Fatalf("KeyID = %q, want %q", a.KeyID, KeyID(pub))
+	}
+
+	if err := SignArtifact(priv, "", &Artifact{}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for missing checksum, got %v", err)
+	}
+}
+
+func TestEncryptedSigningKey(t *testing.T) {
+	fastKDF(t)
+	_, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "keys", "signing.key")
+
+	if err := WriteEncryptedSigningKey(path, priv, NewPassphraseKey("hunter2")); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("key file mode = %o, want 600", perm)
+	}
+
+	got, err := LoadEncryptedSigningKey(path, NewPassphraseKey("hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(priv) {
+		t.Fatal("loaded key differs from written key")
+	}
+
+	if _, err := LoadEncryptedSigningKey(path, NewPassphraseKey("wrong")); !errors.Is(err, ErrDecryptFailed) {
+		t.Fatalf("expected ErrDecryptFailed, got %v", err)
+	}
+}
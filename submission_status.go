@@ -0,0 +1,80 @@
+package registry
+
+// SubmissionStatus is the state of a submission in the review pipeline.
+type SubmissionStatus string
+
+const (
+	// SubmissionDraft is a newly created submission awaiting artifacts.
+	SubmissionDraft SubmissionStatus = "draft"
+	// SubmissionUploading has had upload URLs issued for its artifacts.
+	SubmissionUploading SubmissionStatus = "uploading"
+	// SubmissionValidating is being checked by the registry's validators.
+	SubmissionValidating SubmissionStatus = "validating"
+	// SubmissionPendingReview passed validation and awaits a reviewer.
+	SubmissionPendingReview SubmissionStatus = "pending_review"
+	// SubmissionChangesRequested was returned to the publisher by a reviewer.
+	SubmissionChangesRequested SubmissionStatus = "changes_requested"
+	// SubmissionApproved was accepted by a reviewer and is being published.
+	SubmissionApproved SubmissionStatus = "approved"
+	// SubmissionValidationFailed failed the registry's validators.
+	SubmissionValidationFailed SubmissionStatus = "validation_failed"
+	// SubmissionRejected failed validation or review.
+	SubmissionRejected SubmissionStatus = "rejected"
+	// SubmissionWithdrawn was withdrawn by the publisher.
+	SubmissionWithdrawn SubmissionStatus = "withdrawn"
+	// SubmissionPublished is live in the registry.
+	SubmissionPublished SubmissionStatus = "published"
+)
+
+// submissionTransitions lists the statuses each status may move to.
+var submissionTransitions = map[SubmissionStatus][]SubmissionStatus{
+	SubmissionDraft:            {SubmissionUploading, SubmissionValidating, SubmissionWithdrawn},
+	SubmissionUploading:        {SubmissionValidating, SubmissionWithdrawn},
+	SubmissionValidating:       {SubmissionPendingReview, SubmissionValidationFailed, SubmissionRejected, SubmissionWithdrawn},
+	SubmissionPendingReview:    {SubmissionApproved, SubmissionRejected, SubmissionChangesRequested, SubmissionWithdrawn},
+	SubmissionChangesRequested: {SubmissionUploading, SubmissionValidating, SubmissionWithdrawn},
+	SubmissionApproved:         {SubmissionPublished},
+	SubmissionValidationFailed: nil,
+	SubmissionRejected:         nil,
+	SubmissionWithdrawn:        nil,
+	SubmissionPublished:        nil,
+}
+
+// Known reports whether s is one of the statuses defined by this package.
+func (s SubmissionStatus) Known() bool {
+	_, ok := submissionTransitions[s]
+	return ok
+}
+
+// CanTransition reports whether a submission may move from s to next.
+// Transitions from unknown statuses are allowed so that clients keep working
+// when the registry adds new states.
+func (s SubmissionStatus) CanTransition(next SubmissionStatus) bool {
+	allowed, ok := submissionTransitions[s]
+	if !ok {
+		return true
+	}
+	for _, a := range allowed {
+		if a == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CanSubmit reports whether a submission in status s may be submitted for review.
+func (s SubmissionStatus) CanSubmit() bool {
+	return s.CanTransition(SubmissionValidating)
+}
+
+// CanWithdraw reports whether a submission in status s may be withdrawn.
+func (s SubmissionStatus) CanWithdraw() bool {
+	return s.CanTransition(SubmissionWithdrawn)
+}
+
+// IsTerminal reports whether s is a final status. Unknown statuses are not
+// terminal.
+func (s SubmissionStatus) IsTerminal() bool {
+	allowed, ok := submissionTransitions[s]
+	return ok && len(allowed) == 0
+}
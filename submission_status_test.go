@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubmissionStatus(t *testing.T) {
+	tests := []struct {
+		status                           SubmissionStatus
+		canSubmit, canWithdraw, terminal bool
+	}{
+		{SubmissionDraft, true, true, false},
+		{SubmissionUploading, true, true, false},
+		{SubmissionValidating, false, true, false},
+		{SubmissionPendingReview, false, true, false},
+		{SubmissionChangesRequested, true, true, false},
+		{SubmissionApproved, false, false, false},
+		{SubmissionValidationFailed, false, false, true},
+		{SubmissionRejected, false, false, true},
+		{SubmissionWithdrawn, false, false, true},
+		{SubmissionPublished, false, false, true},
+		{"quarantined", true, true, false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.CanSubmit(); got != tt.canSubmit {
+			t.Errorf("%s.CanSubmit() = %v, want %v", tt.status, got, tt.canSubmit)
+		}
+		if got := tt.status.CanWithdraw(); got != tt.canWithdraw {
+			t.Errorf("%s.CanWithdraw() = %v, want %v", tt.status, got, tt.canWithdraw)
+		}
+		if got := tt.status.IsTerminal(); got != tt.terminal {
+			t.Errorf("%s.IsTerminal() = %v, want %v", tt.status, got, tt.terminal)
+		}
+	}
+	if !SubmissionApproved.CanTransition(SubmissionPublished) || SubmissionPublished.CanTransition(SubmissionDraft) {
+		t.Error("unexpected transition table")
+	}
+}
+
+func TestClient_SubmissionTransitions(t *testing.T) {
+	var actions int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/submissions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected GET %s", r.URL.Path)
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/", func(w http.ResponseWriter, r *http.Request) {
+		actions++
+		w.WriteHeader(http.StatusConflict)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "submission is published"})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	_, err := c.SubmitForReview(ctx, "sub-1")
+	var te *TransitionError
+	if !errors.As(err, &te) || te.Action != "submit" || te.From != "" {
+		t.Fatalf("expected submit TransitionError, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("expected the 409 to be wrapped, got %v", err)
+	}
+	if _, err := c.WithdrawSubmission(ctx, "sub-1"); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+	if actions != 2 {
+		t.Fatalf("expected one request per call, got %d", actions)
+	}
+
+	// The IfAllowed variants check the caller's copy and never reach the server.
+	published := &Submission{ID: "sub-1", Status: SubmissionPublished}
+	if _, err := c.SubmitIfAllowed(ctx, published); !errors.As(err, &te) || te.From != SubmissionPublished {
+		t.Fatalf("expected submit TransitionError from published, got %v", err)
+	}
+	if _, err := c.WithdrawIfAllowed(ctx, published); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+	if actions != 2 {
+		t.Fatalf("illegal transitions should not reach the server, got %d calls", actions)
+	}
+}
@@ -2,29 +2,31 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 )
 
 // Submission represents a plugin submission in the review pipeline.
 type Submission struct {
-	ID                  string     `json:"id"`
-	PublisherID         string     `json:"publisher_id"`
-	PluginID            string     `json:"plugin_id"`
-	Version             string     `json:"version"`
-	Status              string     `json:"status"`
-	ArtifactS3Prefix    string     `json:"artifact_s3_prefix"`
-	Metadata            string     `json:"metadata_json"`
-	ValidationResult    string     `json:"validation_result"`
-	SubmittedByID       uint       `json:"submitted_by_id"`
-	ReviewerID          *uint      `json:"reviewer_id"`
-	ReviewerNotes       string     `json:"reviewer_notes"`
-	Changelog           string     `json:"changelog"`
-	SubmittedAt         *time.Time `json:"submitted_at"`
-	ValidationStartedAt *time.Time `json:"validation_started_at"`
-	ReviewedAt          *time.Time `json:"reviewed_at"`
-	CreatedAt           time.Time  `json:"created_at"`
-	UpdatedAt           time.Time  `json:"updated_at"`
+	ID                  string           `json:"id"`
+	PublisherID         string           `json:"publisher_id"`
+	PluginID            string           `json:"plugin_id"`
+	Version             string           `json:"version"`
+	Status              SubmissionStatus `json:"status"`
+	ArtifactS3Prefix    string           `json:"artifact_s3_prefix"`
+	Metadata            string           `json:"metadata_json"`
+	ValidationResult    string           `json:"validation_result"`
+	SubmittedByID       uint             `json:"submitted_by_id"`
+	ReviewerID          *uint            `json:"reviewer_id"`
+	ReviewerNotes       string           `json:"reviewer_notes"`
+	Changelog           string           `json:"changelog"`
+	SubmittedAt         *time.Time       `json:"submitted_at"`
+	ValidationStartedAt *time.Time       `json:"validation_started_at"`
+	ReviewedAt          *time.Time       `json:"reviewed_at"`
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at"`
 }
 
 // CreateSubmissionRequest is the request body for creating a submission.
@@ -78,21 +80,50 @@ func (c *Client) ListSubmissions(ctx context.Context, publisherSlug string, opts
 	return ListResult[Submission]{Items: items, Pagination: pg}, nil
 }
 
-// SubmitForReview transitions a submission to pending review.
+// SubmitForReview transitions a submission to pending review. If the server
+// rejects the transition for the submission's current status, a
+// *TransitionError is returned.
 func (c *Client) SubmitForReview(ctx context.Context, id string) (*Submission, error) {
-	var sub Submission
-	path := fmt.Sprintf("/v1/submissions/%s/submit", id)
-	if err := c.post(ctx, path, nil, &sub); err != nil {
-		return nil, err
-	}
-	return &sub, nil
+	return c.submissionAction(ctx, id, "submit")
 }
 
-// WithdrawSubmission withdraws a submission from review.
+// WithdrawSubmission withdraws a submission from review. If the server
+// rejects the transition for the submission's current status, a
+// *TransitionError is returned.
 func (c *Client) WithdrawSubmission(ctx context.Context, id string) (*Submission, error) {
+	return c.submissionAction(ctx, id, "withdraw")
+}
+
+// SubmitIfAllowed submits sub for review like SubmitForReview, but returns a
+// *TransitionError without contacting the server if sub.Status does not
+// allow it.
+func (c *Client) SubmitIfAllowed(ctx context.Context, sub *Submission) (*Submission, error) {
+	if !sub.Status.CanSubmit() {
+		return nil, &TransitionError{SubmissionID: sub.ID, From: sub.Status, Action: "submit"}
+	}
+	return c.SubmitForReview(ctx, sub.ID)
+}
+
+// WithdrawIfAllowed withdraws sub like WithdrawSubmission, but returns a
+// *TransitionError without contacting the server if sub.Status does not
+// allow it.
+func (c *Client) WithdrawIfAllowed(ctx context.Context, sub *Submission) (*Submission, error) {
+	if !sub.Status.CanWithdraw() {
+		return nil, &TransitionError{SubmissionID: sub.ID, From: sub.Status, Action: "withdraw"}
+	}
+	return c.WithdrawSubmission(ctx, sub.ID)
+}
+
+// submissionAction posts to a submission action endpoint, mapping a 409
+// response to a *TransitionError.
+func (c *Client) submissionAction(ctx context.Context, id, action string) (*Submission, error) {
 	var sub Submission
-	path := fmt.Sprintf("/v1/submissions/%s/withdraw", id)
+	path := fmt.Sprintf("/v1/submissions/%s/%s", id, action)
 	if err := c.post(ctx, path, nil, &sub); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			return nil, &TransitionError{SubmissionID: id, Action: action, Err: err}
+		}
 		return nil, err
 	}
 	return &sub, nil
@@ -107,3 +138,77 @@ func (c *Client) GenerateUploadURLs(ctx context.Context, id string, architecture
 	}
 	return &resp, nil
 }
+
+// CreateMultipartUploadRequest is the request body for starting a multipart artifact upload.
+type CreateMultipartUploadRequest struct {
+	Architecture string `json:"architecture"`
+	Size         int64  `json:"size"`
+	PartSize     int64  `json:"part_size"`
+	Checksum     string `json:"checksum"` // SHA-256 hex of the whole artifact
+}
+
+// MultipartUpload is an in-progress multipart artifact upload.
+type MultipartUpload struct {
+	ID           string          `json:"id"`
+	Architecture string          `json:"architecture"`
+	PartSize     int64           `json:"part_size"`
+	Parts        []PresignedPart `json:"parts"`
+}
+
+// PresignedPart is a presigned URL for one part of a multipart upload.
+type PresignedPart struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// CompletedPart identifies an uploaded part when completing a multipart upload.
+type CompletedPart struct {
+	Number   int    `json:"number"`
+	ETag     string `json:"etag"`
+	Checksum string `json:"checksum"` // SHA-256 base64
+}
+
+// CompleteMultipartUploadRequest is the request body for completing a multipart upload.
+type CompleteMultipartUploadRequest struct {
+	Parts []CompletedPart `json:"parts"`
+}
+
+// refreshPartURLsRequest is the request body for re-signing part URLs.
+type refreshPartURLsRequest struct {
+	PartNumbers []int `json:"part_numbers"`
+}
+
+// CreateMultipartUpload starts a multipart upload for one submission artifact
+// and returns presigned URLs for every part.
+func (c *Client) CreateMultipartUpload(ctx context.Context, id string, req *CreateMultipartUploadRequest) (*MultipartUpload, error) {
+	var mu MultipartUpload
+	path := fmt.Sprintf("/v1/submissions/%s/multipart-uploads", id)
+	if err := c.post(ctx, path, req, &mu); err != nil {
+		return nil, err
+	}
+	return &mu, nil
+}
+
+// RefreshPartURLs returns freshly signed URLs for the given parts of a multipart upload.
+func (c *Client) RefreshPartURLs(ctx context.Context, id, uploadID string, partNumbers []int) ([]PresignedPart, error) {
+	var resp struct {
+		Parts []PresignedPart `json:"parts"`
+	}
+	path := fmt.Sprintf("/v1/submissions/%s/multipart-uploads/%s/part-urls", id, uploadID)
+	if err := c.post(ctx, path, &refreshPartURLsRequest{PartNumbers: partNumbers}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Parts, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the artifact.
+func (c *Client) CompleteMultipartUpload(ctx context.Context, id, uploadID string, parts []CompletedPart) error {
+	path := fmt.Sprintf("/v1/submissions/%s/multipart-uploads/%s/complete", id, uploadID)
+	return c.post(ctx, path, &CompleteMultipartUploadRequest{Parts: parts}, nil)
+}
+
+// AbortMultipartUpload discards a multipart upload and any parts already uploaded.
+func (c *Client) AbortMultipartUpload(ctx context.Context, id, uploadID string) error {
+	path := fmt.Sprintf("/v1/submissions/%s/multipart-uploads/%s", id, uploadID)
+	return c.del(ctx, path, nil)
+}
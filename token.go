@@ -0,0 +1,276 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultClockSkew is the expiry tolerance used when none is configured. A
+// token expiring within this window is treated as already expired.
+const DefaultClockSkew = 30 * time.Second
+
+// timeNow is the clock used for expiry checks; tests may replace it.
+var timeNow = time.Now
+
+// TokenClaims holds the commonly used claims of a registry JWT. Claims are
+// decoded without verifying the token's signature and must not be used for
+// authorization decisions.
+type TokenClaims struct {
+	Subject   string
+	Issuer    string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+	Scopes    []string
+}
+
+// Expired reports whether the token expires within skew of now.
+// Tokens without an expiry never expire.
+func (tc *TokenClaims) Expired(skew time.Duration) bool {
+	if tc.ExpiresAt.IsZero() {
+		return false
+	}
+	return !timeNow().Add(skew).Before(tc.ExpiresAt)
+}
+
+// HasScope reports whether scope is among the token's scopes.
+func (tc *TokenClaims) HasScope(scope string) bool {
+	for _, s := range tc.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// rawClaims mirrors the JWT payload fields we understand. Scopes may be
+// carried as a space-separated "scope" string or a "scopes"/"scp" array.
+type rawClaims struct {
+	Sub    string          `json:"sub"`
+	Iss    string          `json:"iss"`
+	Exp    json.Number     `json:"exp"`
+	Iat    json.Number     `json:"iat"`
+	Scope  json.RawMessage `json:"scope"`
+	Scopes []string        `json:"scopes"`
+	Scp    []string        `json:"scp"`
+}
+
+// ParseTokenClaims decodes the claims of a JWT without verifying its signature.
+func ParseTokenClaims(token string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformedToken, len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decoding payload: %v", ErrMalformedToken, err)
+	}
+
+	var raw rawClaims
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("%w: decoding claims: %v", ErrMalformedToken, err)
+	}
+
+	claims := &TokenClaims{Subject: raw.Sub, Issuer: raw.Iss}
+	if claims.ExpiresAt, err = unixClaim(raw.Exp); err != nil {
+		return nil, fmt.Errorf("%w: exp: %v", ErrMalformedToken, err)
+	}
+	if claims.IssuedAt, err = unixClaim(raw.Iat); err != nil {
+		return nil, fmt.Errorf("%w: iat: %v", ErrMalformedToken, err)
+	}
+
+	switch {
+	case len(raw.Scope) > 0:
+		var s string
+		if json.Unmarshal(raw.Scope, &s) == nil {
+			claims.Scopes = strings.Fields(s)
+		} else if err := json.Unmarshal(raw.Scope, &claims.Scopes); err != nil {
+			return nil, fmt.Errorf("%w: scope: %v", ErrMalformedToken, err)
+		}
+	case len(raw.Scopes) > 0:
+		claims.Scopes = raw.Scopes
+	default:
+		claims.Scopes = raw.Scp
+	}
+	return claims, nil
+}
+
+func unixClaim(n json.Number) (time.Time, error) {
+	if n == "" {
+		return time.Time{}, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(f), 0), nil
+}
+
+// Token is a bearer token together with its expiry.
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// valid reports whether the token is non-empty and does not expire within skew.
+func (t *Token) valid(skew time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry.IsZero() {
+		return true
+	}
+	return timeNow().Add(skew).Before(t.Expiry)
+}
+
+// NewToken returns a Token whose expiry is read from the JWT's exp claim.
+// Opaque tokens are returned without an expiry.
+func NewToken(accessToken string) *Token {
+	tok := &Token{AccessToken: accessToken}
+	if claims, err := ParseTokenClaims(accessToken); err == nil {
+		tok.Expiry = claims.ExpiresAt
+	}
+	return tok
+}
+
+// TokenSource supplies bearer tokens for authenticated requests. A nil token
+// with a nil error means no token is available, and the request is sent with
+// the client's stored credentials, if any.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns the same token.
+func StaticTokenSource(accessToken string) TokenSource {
+	tok := NewToken(accessToken)
+	return TokenSourceFunc(func(context.Context) (*Token, error) { return tok, nil })
+}
+
+// RefreshingTokenSource caches a token from an underlying source and fetches a
+// new one when the cached token comes within the clock-skew tolerance of its
+// expiry, or after Invalidate is called.
+type RefreshingTokenSource struct {
+	src  TokenSource
+	skew time.Duration
+
+	mu  sync.Mutex
+	tok *Token
+}
+
+// NewRefreshingTokenSource wraps src with caching and proactive refresh.
+// initial may be nil. A zero skew uses DefaultClockSkew.
+func NewRefreshingTokenSource(initial *Token, src TokenSource, skew time.Duration) *RefreshingTokenSource {
+	if skew == 0 {
+		skew = DefaultClockSkew
+	}
+	return &RefreshingTokenSource{src: src, skew: skew, tok: initial}
+}
+
+// Token returns the cached token, refreshing it from the underlying source if
+// it is missing or about to expire.
+func (s *RefreshingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tok.valid(s.skew) {
+		return s.tok, nil
+	}
+	tok, err := s.src.Token(ctx)
+	if err != nil || tok == nil {
+		return nil, err
+	}
+	s.tok = tok
+	return tok, nil
+}
+
+// Invalidate discards the cached token so the next call to Token refreshes it.
+func (s *RefreshingTokenSource) Invalidate() {
+	s.mu.Lock()
+	s.tok = nil
+	s.mu.Unlock()
+}
+
+// WithTokenSource sets a TokenSource used to obtain the bearer token for each
+// request. If the source has an Invalidate method (as RefreshingTokenSource
+// does), a 401 response causes it to be invalidated and the request retried once.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) { c.tokenSource = ts }
+}
+
+// WithExpiryWarning registers fn to be called when the bearer token in use
+// expires within skew. fn is called at most once per token.
+func WithExpiryWarning(skew time.Duration, fn func(claims *TokenClaims)) Option {
+	return func(c *Client) { c.expiryWarn = &expiryWarning{skew: skew, fn: fn} }
+}
+
+type expiryWarning struct {
+	skew time.Duration
+	fn   func(claims *TokenClaims)
+
+	mu     sync.Mutex
+	warned string
+}
+
+// checkTokenExpiry fires the expiry warning if token is close to expiring.
+func (c *Client) checkTokenExpiry(token string) {
+	w := c.expiryWarn
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	if w.warned == token {
+		w.mu.Unlock()
+		return
+	}
+	claims, err := ParseTokenClaims(token)
+	if err != nil || !claims.Expired(w.skew) {
+		w.mu.Unlock()
+		return
+	}
+	w.warned = token
+	w.mu.Unlock()
+	w.fn(claims)
+}
+
+// invalidateToken invalidates the client's token source if it supports it,
+// reporting whether a retry may yield a different token.
+func (c *Client) invalidateToken() bool {
+	_, _, ts := c.credentials()
+	inv, ok := ts.(interface{ Invalidate() })
+	if !ok {
+		return false
+	}
+	inv.Invalidate()
+	return true
+}
+
+// TokenClaims decodes the claims of the client's configured JWT without
+// verifying it.
+func (c *Client) TokenClaims(ctx context.Context) (*TokenClaims, error) {
+	_, token, ts := c.credentials()
+	if ts != nil {
+		tok, err := ts.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("obtaining token: %w", err)
+		}
+		if tok != nil {
+			token = tok.AccessToken
+		}
+	}
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	return ParseTokenClaims(token)
+}
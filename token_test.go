@@ -0,0 +1,219 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// makeJWT builds an unsigned JWT carrying the given claims.
+func makeJWT(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+// freezeTime pins timeNow for the duration of a test.
+func freezeTime(t *testing.T, now time.Time) {
+	t.Helper()
+	original := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = original })
+}
+
+func TestParseTokenClaims(t *testing.T) {
+	exp := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tok := makeJWT(t, map[string]interface{}{
+		"sub":   "42",
+		"iss":   "omniview",
+		"exp":   exp.Unix(),
+		"iat":   exp.Add(-time.Hour).Unix(),
+		"scope": "plugins:read plugins:publish",
+	})
+
+	claims, err := ParseTokenClaims(tok)
+	if err != nil {
+		t.Fatalf("ParseTokenClaims() error: %v", err)
+	}
+	if claims.Subject != "42" {
+		t.Fatalf("expected subject 42, got %s", claims.Subject)
+	}
+	if !claims.ExpiresAt.Equal(exp) {
+		t.Fatalf("expected expiry %v, got %v", exp, claims.ExpiresAt)
+	}
+	if !claims.HasScope("plugins:publish") {
+		t.Fatalf("expected plugins:publish scope, got %v", claims.Scopes)
+	}
+}
+
+func TestParseTokenClaims_scopesArray(t *testing.T) {
+	tok := makeJWT(t, map[string]interface{}{"scopes": []string{"a", "b"}})
+	claims, err := ParseTokenClaims(tok)
+	if err != nil {
+		t.Fatalf("ParseTokenClaims() error: %v", err)
+	}
+	if len(claims.Scopes) != 2 {
+		t.Fatalf("expected 2 scopes, got %v", claims.Scopes)
+	}
+}
+
+func TestParseTokenClaims_malformed(t *testing.T) {
+	for _, tok := range []string{"", "opaque", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".c"} {
+		if _, err := ParseTokenClaims(tok); !errors.Is(err, ErrMalformedToken) {
+			t.Errorf("ParseTokenClaims(%q): expected ErrMalformedToken, got %v", tok, err)
+		}
+	}
+}
+
+func TestTokenClaims_Expired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	freezeTime(t, now)
+
+	claims := &TokenClaims{ExpiresAt: now.Add(20 * time.Second)}
+	if claims.Expired(0) {
+		t.Fatal("expected token to be valid without skew")
+	}
+	if !claims.Expired(30 * time.Second) {
+		t.Fatal("expected token to be expired within skew")
+	}
+	if (&TokenClaims{}).Expired(time.Hour) {
+		t.Fatal("expected token without exp to never expire")
+	}
+}
+
+func TestRefreshingTokenSource_refreshesBeforeExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	freezeTime(t, now)
+
+	var calls int
+	src := TokenSourceFunc(func(context.Context) (*Token, error) {
+		calls++
+		return &Token{AccessToken: "fresh", Expiry: now.Add(time.Hour)}, nil
+	})
+	ts := NewRefreshingTokenSource(&Token{AccessToken: "stale", Expiry: now.Add(10 * time.Second)}, src, time.Minute)
+
+	tok, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "fresh" {
+		t.Fatalf("expected proactive refresh, got %s", tok.AccessToken)
+	}
+	if _, err := ts.Token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected cached token to be reused, got %d refreshes", calls)
+	}
+}
+
+func TestClient_retriesOnceAfter401(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			writeJSON(w, map[string]interface{}{"success": false, "message": "token expired"})
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"success": true,
+			"data":    map[string]interface{}{"id": 1, "username": "me"},
+		})
+	}))
+	defer srv.Close()
+
+	var calls int32
+	src := TokenSourceFunc(func(context.Context) (*Token, error) {
+		atomic.AddInt32(&calls, 1)
+		return &Token{AccessToken: "good"}, nil
+	})
+	ts := NewRefreshingTokenSource(&Token{AccessToken: "revoked"}, src, 0)
+
+	c := NewClient(WithBaseURL(srv.URL), WithTokenSource(ts))
+	user, err := c.GetMe(context.Background())
+	if err != nil {
+		t.Fatalf("GetMe() error: %v", err)
+	}
+	if user.Username != "me" {
+		t.Fatalf("expected me, got %s", user.Username)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected 1 refresh, got %d", calls)
+	}
+}
+
+func TestClient_noRetryWithoutRefreshableSource(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		writeJSON(w, map[string]interface{}{"success": false, "message": "unauthorized"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("static"))
+	if _, err := c.GetMe(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Fatalf("expected a single request, got %d", hits)
+	}
+}
+
+func TestClient_nilTokenFromSource(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]string{"status": "ok"}})
+	}))
+	defer srv.Close()
+
+	src := TokenSourceFunc(func(context.Context) (*Token, error) { return nil, nil })
+	for name, ts := range map[string]TokenSource{"func": src, "refreshing": NewRefreshingTokenSource(nil, src, 0)} {
+		c := NewClient(WithBaseURL(srv.URL), WithTokenSource(ts))
+		if _, err := c.Health(context.Background()); err != nil {
+			t.Fatalf("%s: Health() error: %v", name, err)
+		}
+		if auth != "" {
+			t.Fatalf("%s: expected no Authorization header, got %q", name, auth)
+		}
+		if _, err := c.TokenClaims(context.Background()); !errors.Is(err, ErrNoToken) {
+			t.Fatalf("%s: expected ErrNoToken, got %v", name, err)
+		}
+	}
+}
+
+func TestClient_WithExpiryWarning(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	freezeTime(t, now)
+
+	srv := fakeAPI(t)
+	defer srv.Close()
+
+	tok := makeJWT(t, map[string]interface{}{"sub": "42", "exp": now.Add(time.Minute).Unix()})
+	var warned []*TokenClaims
+	c := NewClient(WithBaseURL(srv.URL), WithToken(tok), WithExpiryWarning(5*time.Minute, func(claims *TokenClaims) {
+		warned = append(warned, claims)
+	}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Health(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(warned) != 1 {
+		t.Fatalf("expected exactly 1 warning, got %d", len(warned))
+	}
+	if warned[0].Subject != "42" {
+		t.Fatalf("expected subject 42, got %s", warned[0].Subject)
+	}
+}
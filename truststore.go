@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TrustedKey is an Ed25519 public key trusted to sign artifacts.
+type TrustedKey struct {
+	// ID identifies the key in Artifact.KeyID. Defaults to KeyID(PublicKey).
+	ID        string
+	PublicKey ed25519.PublicKey
+	// NotBefore and NotAfter bound when the key is trusted. Zero values leave
+	// the window open on that side.
+	NotBefore time.Time
+	NotAfter  time.Time
+	// Revoked keys are never trusted.
+	Revoked bool
+}
+
+// validAt returns nil if k is trusted at t, or the reason it is not.
+func (k *TrustedKey) validAt(t time.Time) error {
+	switch {
+	case k.Revoked:
+		return fmt.Errorf("%w: key %s", ErrSigningKeyRevoked, k.ID)
+	case !k.NotBefore.IsZero() && t.Before(k.NotBefore):
+		return fmt.Errorf("%w: key %s is not valid until %s", ErrSigningKeyExpired, k.ID, k.NotBefore.Format(time.RFC3339))
+	case !k.NotAfter.IsZero() && !t.Before(k.NotAfter):
+		return fmt.Errorf("%w: key %s expired at %s", ErrSigningKeyExpired, k.ID, k.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// KeyID returns the default ID for pub: the first 8 bytes of its SHA-256
+// hash, hex-encoded.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// TrustStore holds the keys trusted to sign artifacts. Keeping retired keys in
+// the store, rather than replacing them, lets artifacts signed before a key
+// rotation continue to verify. It is safe for concurrent use.
+type TrustStore struct {
+	mu   sync.RWMutex
+	keys map[string]TrustedKey
+}
+
+// NewTrustStore returns a store holding keys.
+func NewTrustStore(keys ...TrustedKey) (*TrustStore, error) {
+	s := &TrustStore{keys: make(map[string]TrustedKey, len(keys))}
+	for _, k := range keys {
+		if err := s.Add(k); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// DefaultTrustStore returns a new store holding the embedded Omniview key, as
+// set by SetPublicKey, under its default ID.
+func DefaultTrustStore() *TrustStore {
+	pub := omniviewPublicKey
+	id := KeyID(pub)
+	return &TrustStore{keys: map[string]TrustedKey{id: {ID: id, PublicKey: pub}}}
+}
+
+// Add adds k to the store, replacing any key with the same ID.
+func (s *TrustStore) Add(k TrustedKey) error {
+	if len(k.PublicKey) != ed25519.PublicKeySize {
+		return &FieldError{Field: "public_key", Message: fmt.Sprintf("must be %d bytes, got %d", ed25519.PublicKeySize, len(k.PublicKey))}
+	}
+	if k.ID == "" {
+		k.ID = KeyID(k.PublicKey)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		s.keys = map[string]TrustedKey{}
+	}
+	s.keys[k.ID] = k
+	return nil
+}
+
+// Revoke marks the key with the given ID as revoked.
+func (s *TrustStore) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, ok := s.keys[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSigningKey, id)
+	}
+	k.Revoked = true
+	s.keys[id] = k
+	return nil
+}
+
+// Key returns the key with the given ID.
+func (s *TrustStore) Key(id string) (TrustedKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	k, ok := s.keys[id]
+	return k, ok
+}
+
+// Keys returns every key in the store, sorted by ID.
+func (s *TrustStore) Keys() []TrustedKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]TrustedKey, 0, len(s.keys))
+	for _, id := range sortedKeys(s.keys) {
+		keys = append(keys, s.keys[id])
+	}
+	return keys
+}
+
+// Verify checks that signatureB64 is a signature of checksum by the key with
+// the given ID. If keyID is empty, as for artifacts signed before key IDs were
+// recorded, or names a key not in the store, every key currently trusted is
+// tried; the signature itself is what establishes trust, so a registry that
+// names its keys differently from KeyID still verifies. A known key that is
+// revoked or outside its validity window is rejected.
+func (s *TrustStore) Verify(checksum, keyID, signatureB64 string) error {
+	_, err := s.verify(checksum, keyID, signatureB64)
+	return err
+}
+
+// verify is Verify, returning the ID of the key that produced the signature.
+func (s *TrustStore) verify(checksum, keyID, signatureB64 string) (string, error) {
+	if signatureB64 == "" {
+		return "", ErrUnsignedArtifact
+	}
+	sig, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed base64: %v", ErrInvalidSignature, err)
+	}
+
+	now := timeNow()
+	k, ok := s.Key(keyID)
+	if !ok {
+		for _, k := range s.Keys() {
+			if k.validAt(now) == nil && ed25519.Verify(k.PublicKey, []byte(checksum), sig) {
+				return k.ID, nil
+			}
+		}
+		if keyID == "" {
+			return "", ErrInvalidSignature
+		}
+		return "", fmt.Errorf("%w: %w: %s", ErrInvalidSignature, ErrUnknownSigningKey, keyID)
+	}
+	if err := k.validAt(now); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if !ed25519.Verify(k.PublicKey, []byte(checksum), sig) {
+		return "", ErrInvalidSignature
+	}
+	return k.ID, nil
+}
+
+// VerifyArtifact verifies a's signature of checksum using a.KeyID.
+func (s *TrustStore) VerifyArtifact(checksum string, a *Artifact) error {
+	return s.Verify(checksum, a.KeyID, a.Signature)
+}
+
+// WithTrustStore sets the keys the client trusts when verifying downloaded
+// artifacts. The default is DefaultTrustStore.
+func WithTrustStore(s *TrustStore) Option {
+	return func(c *Client) { c.trust = s }
+}
+
+// TrustStore returns the store the client verifies artifacts against.
+func (c *Client) TrustStore() *TrustStore {
+	if c.trust != nil {
+		return c.trust
+	}
+	return DefaultTrustStore()
+}
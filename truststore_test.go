@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func TestTrustStore_rotation(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	freezeTime(t, now)
+
+	oldPub, oldPriv := newTestKey(t)
+	newPub, newPriv := newTestKey(t)
+	s, err := NewTrustStore(
+		TrustedKey{ID: "2025", PublicKey: oldPub},
+		TrustedKey{ID: "2026", PublicKey: newPub, NotBefore: now.Add(-time.Hour)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const checksum = "abc123"
+	if err := s.Verify(checksum, "2025", SignChecksum(oldPriv, checksum)); err != nil {
+		t.Fatalf("old key: %v", err)
+	}
+	if err := s.Verify(checksum, "2026", SignChecksum(newPriv, checksum)); err != nil {
+		t.Fatalf("new key: %v", err)
+	}
+	if err := s.Verify(checksum, "2026", SignChecksum(oldPriv, checksum)); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("signature by the wrong key: expected ErrInvalidSignature, got %v", err)
+	}
+	if err := s.Verify(checksum, "", SignChecksum(newPriv, checksum)); err != nil {
+		t.Fatalf("no key ID: %v", err)
+	}
+}
+
+func TestTrustStore_rejectsUntrustedKeys(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	freezeTime(t, now)
+
+	pub, priv := newTestKey(t)
+	const checksum = "abc123"
+	sig := SignChecksum(priv, checksum)
+
+	tests := []struct {
+		name string
+		key  TrustedKey
+		want error
+	}{
+		{"revoked", TrustedKey{ID: "k", PublicKey: pub, Revoked: true}, ErrSigningKeyRevoked},
+		{"expired", TrustedKey{ID: "k", PublicKey: pub, NotAfter: now}, ErrSigningKeyExpired},
+		{"not yet valid", TrustedKey{ID: "k", PublicKey: pub, NotBefore: now.Add(time.Minute)}, ErrSigningKeyExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewTrustStore(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Verify(checksum, "k", sig)
+			if !errors.Is(err, tt.want) || !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("expected %v wrapping ErrInvalidSignature, got %v", tt.want, err)
+			}
+			if err := s.Verify(checksum, "", sig); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("fallback without key ID: expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTrustStore_unknownKeyAndRevoke(t *testing.T) {
+	pub, priv := newTestKey(t)
+	s, err := NewTrustStore(TrustedKey{PublicKey: pub})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := KeyID(pub)
+	if _, ok := s.Key(id); !ok {
+		t.Fatalf("key not stored under default ID %q", id)
+	}
+
+	sig := SignChecksum(priv, "abc")
+	if err := s.Verify("abc", "other", sig); err != nil {
+		t.Fatalf("unknown key ID should fall back to the trusted keys, got %v", err)
+	}
+	_, otherPriv := newTestKey(t)
+	err = s.Verify("abc", "other", SignChecksum(otherPriv, "abc"))
+	if !errors.Is(err, ErrUnknownSigningKey) || !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrUnknownSigningKey, got %v", err)
+	}
+	if err := s.Revoke("other"); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("expected ErrUnknownSigningKey revoking missing key, got %v", err)
+	}
+	if err := s.Revoke(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify("abc", id, sig); !errors.Is(err, ErrSigningKeyRevoked) {
+		t.Fatalf("expected ErrSigningKeyRevoked, got %v", err)
+	}
+
+	if err := s.Add(TrustedKey{ID: "short", PublicKey: pub[:8]}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for short key, got %v", err)
+	}
+}
+
+func TestClient_TrustStore(t *testing.T) {
+	pub, _ := newTestKey(t)
+	swapPublicKey(t, pub)
+
+	c := NewClient()
+	if keys := c.TrustStore().Keys(); len(keys) != 1 || !keys[0].PublicKey.Equal(pub) {
+		t.Fatalf("default trust store = %+v, want the embedded key", keys)
+	}
+
+	s, err := NewTrustStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = NewClient(WithTrustStore(s))
+	if c.TrustStore() != s || c.Clone().TrustStore() != s {
+		t.Fatal("WithTrustStore not applied or not carried over by Clone")
+	}
+}
+
+func TestVerifyArtifactSignatureWithKey(t *testing.T) {
+	pub, priv := newTestKey(t)
+	swapPublicKey(t, pub)
+
+	const checksum = "abc123"
+	sig := SignChecksum(priv, checksum)
+	for _, id := range []string{KeyID(pub), "omniview-2026", ""} {
+		if err := VerifyArtifactSignatureWithKey(checksum, id, sig); err != nil {
+			t.Errorf("key ID %q: %v", id, err)
+		}
+	}
+	if err := VerifyArtifactSignatureWithKey("other", KeyID(pub), sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
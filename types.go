@@ -4,28 +4,33 @@ import "time"
 
 // Plugin represents a plugin in the registry.
 type Plugin struct {
-	ID            string         `json:"id"`
-	Name          string         `json:"name"`
-	Description   string         `json:"description"`
-	IconURL       string         `json:"icon_url"`
-	Category      string         `json:"category"`
-	Tags          []string       `json:"tags"`
-	License       string         `json:"license"`
-	Repository    string         `json:"repository"`
-	URL           string         `json:"url"`
-	Readme        string         `json:"readme"`
-	Official      bool           `json:"official"`
-	Featured      bool           `json:"featured"`
-	DownloadCount int64          `json:"download_count"`
-	AverageRating float64        `json:"average_rating"`
-	ReviewCount   int64          `json:"review_count"`
-	PublisherID   string         `json:"publisher_id"`
-	PublisherName string         `json:"publisher_name"`
-	LatestVersion string         `json:"latest_version"`
-	Version       *PluginVersion `json:"version,omitempty"`
-	Author        PluginAuthor   `json:"author"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID                 string             `json:"id"`
+	Name               string             `json:"name"`
+	Description        string             `json:"description"`
+	IconURL            string             `json:"icon_url"`
+	Category           string             `json:"category"`
+	Tags               []string           `json:"tags"`
+	License            string             `json:"license"`
+	Repository         string             `json:"repository"`
+	URL                string             `json:"url"`
+	Readme             string             `json:"readme"`
+	Official           bool               `json:"official"`
+	Featured           bool               `json:"featured"`
+	DownloadCount      int64              `json:"download_count"`
+	AverageRating      float64            `json:"average_rating"`
+	ReviewCount        int64              `json:"review_count"`
+	PublisherID        string             `json:"publisher_id"`
+	PublisherName      string             `json:"publisher_name"`
+	LatestVersion      string             `json:"latest_version"`
+	LatestVersions     map[Channel]string `json:"latest_versions,omitempty"`
+	Deprecated         bool               `json:"deprecated"`
+	DeprecationMessage string             `json:"deprecation_message,omitempty"`
+	ReplacementID      string             `json:"replacement_id,omitempty"`
+	Unlisted           bool               `json:"unlisted"`
+	Version            *PluginVersion     `json:"version,omitempty"`
+	Author             PluginAuthor       `json:"author"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
 }
 
 // PluginAuthor identifies the author of a plugin.
@@ -45,7 +50,12 @@ type PluginVersion struct {
 	MinIDEVersion string              `json:"min_ide_version"`
 	MaxIDEVersion string              `json:"max_ide_version"`
 	Capabilities  []string            `json:"capabilities"`
+	Channel       Channel             `json:"channel,omitempty"`
 	Visible       bool                `json:"visible"`
+	Yanked        bool                `json:"yanked"`
+	YankReason    string              `json:"yank_reason,omitempty"`
+	YankedAt      *time.Time          `json:"yanked_at,omitempty"`
+	Rollout       *Rollout            `json:"rollout,omitempty"`
 	Artifacts     map[string]Artifact `json:"artifacts,omitempty"`
 	CreatedAt     time.Time           `json:"created_at"`
 	UpdatedAt     time.Time           `json:"updated_at"`
@@ -53,10 +63,14 @@ type PluginVersion struct {
 
 // Artifact represents a platform-specific build artifact.
 type Artifact struct {
-	Checksum    string `json:"checksum"`     // SHA-256 hex
-	Signature   string `json:"signature"`    // base64 Ed25519
-	DownloadURL string `json:"download_url"` // relative CDN path
-	Size        int64  `json:"size"`
+	Checksum  string `json:"checksum"`  // SHA-256 hex
+	Signature string `json:"signature"` // base64 Ed25519
+	KeyID     string `json:"key_id,omitempty"`
+	// Signatures holds co-signatures, such as the publisher's, alongside
+	// the registry signature above.
+	Signatures  []ArtifactSignature `json:"signatures,omitempty"`
+	DownloadURL string              `json:"download_url"` // relative CDN path
+	Size        int64               `json:"size"`
 }
 
 // Review represents a user review of a plugin.
@@ -104,6 +118,29 @@ type PublisherAccess struct {
 	CanManageMembers bool   `json:"can_manage_members"`
 }
 
+// Permission is a publisher-scoped capability. The values mirror the Can*
+// fields of PublisherAccess.
+type Permission string
+
+const (
+	PermissionPublish       Permission = "publish"
+	PermissionManagePlugins Permission = "manage_plugins"
+	PermissionManageMembers Permission = "manage_members"
+)
+
+// Has reports whether the access grants permission p.
+func (a *PublisherAccess) Has(p Permission) bool {
+	switch p {
+	case PermissionPublish:
+		return a.CanPublish
+	case PermissionManagePlugins:
+		return a.CanManagePlugins
+	case PermissionManageMembers:
+		return a.CanManageMembers
+	}
+	return false
+}
+
 // DownloadStats holds aggregate download statistics for a plugin.
 type DownloadStats struct {
 	PluginID   string             `json:"plugin_id"`
@@ -0,0 +1,400 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPartSize is the multipart part size used when Uploader.PartSize is zero.
+	DefaultPartSize = 8 << 20
+	// DefaultMultipartThreshold is the artifact size at or above which
+	// uploads are split into parts when Uploader.MultipartThreshold is zero.
+	DefaultMultipartThreshold = 64 << 20
+	// DefaultUploadConcurrency is the number of parts uploaded in parallel
+	// when Uploader.Concurrency is zero.
+	DefaultUploadConcurrency = 4
+)
+
+// defaultUploadRetry is the retry policy of a new Uploader.
+var defaultUploadRetry = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+// Uploader uploads submission artifacts to presigned URLs. Every request
+// carries Content-Length and a SHA-256 checksum so the storage backend can
+// reject corrupted bodies. Artifacts at or above MultipartThreshold are split
+// into parts uploaded in parallel, each retried on its own. Expired
+// presigned URLs are re-requested from the registry automatically.
+//
+// Presigned URLs carry their own authorization, so the client's credentials
+// and call headers are never sent to them. The client-wide timeout does not
+// apply to artifact PUTs; bound them with ctx or CallTimeout instead.
+type Uploader struct {
+	client *Client
+
+	// PartSize is the requested size of each multipart part. The registry
+	// may override it.
+	PartSize int64
+	// MultipartThreshold is the artifact size at which multipart is used.
+	MultipartThreshold int64
+	// Concurrency is the number of parts uploaded in parallel.
+	Concurrency int
+	// Retry controls retries of each PUT. Network errors, 5xx and 429
+	// responses and expired URLs are retried.
+	Retry RetryPolicy
+}
+
+// NewUploader returns an Uploader with default settings.
+func (c *Client) NewUploader() *Uploader {
+	return &Uploader{
+		client:             c,
+		PartSize:           DefaultPartSize,
+		MultipartThreshold: DefaultMultipartThreshold,
+		Concurrency:        DefaultUploadConcurrency,
+		Retry:              defaultUploadRetry,
+	}
+}
+
+// Upload uploads the archive at path as the artifact for platform of the
+// submission with the given ID.
+func (u *Uploader) Upload(ctx context.Context, id, platform, path string) error {
+	a, err := hashArtifact(path)
+	if err != nil {
+		return err
+	}
+	return u.upload(ctx, id, platform, a)
+}
+
+func (u *Uploader) upload(ctx context.Context, id, platform string, a *localArtifact) error {
+	threshold := u.MultipartThreshold
+	if threshold <= 0 {
+		threshold = DefaultMultipartThreshold
+	}
+	if a.size >= threshold {
+		return u.uploadMultipart(ctx, id, platform, a)
+	}
+	return u.uploadSingle(ctx, id, platform, a)
+}
+
+// uploadSingle uploads a in one PUT.
+func (u *Uploader) uploadSingle(ctx context.Context, id, platform string, a *localArtifact) error {
+	var target string
+	refresh := func() error {
+		resp, err := u.client.GenerateUploadURLs(ctx, id, []string{platform})
+		if err != nil {
+			return fmt.Errorf("getting upload URL: %w", err)
+		}
+		var ok bool
+		if target, ok = resp.URLs[platform]; !ok {
+			return fmt.Errorf("no upload URL returned for %s", platform)
+		}
+		return nil
+	}
+	if err := refresh(); err != nil {
+		return err
+	}
+
+	return u.withRetry(ctx, func() (bool, error) {
+		if presignedURLExpired(target) {
+			if err := refresh(); err != nil {
+				return false, err
+			}
+		}
+		_, retryable, err := u.put(ctx, target, a.path, 0, a.size, a.sha256)
+		if errors.Is(err, ErrUploadURLExpired) {
+			if rerr := refresh(); rerr != nil {
+				return false, rerr
+			}
+		}
+		return retryable, err
+	})
+}
+
+// uploadMultipart uploads a as parallel parts and completes the upload. On
+// failure the multipart upload is aborted.
+func (u *Uploader) uploadMultipart(ctx context.Context, id, platform string, a *localArtifact) error {
+	partSize := u.PartSize
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+	mu, err := u.client.CreateMultipartUpload(ctx, id, &CreateMultipartUploadRequest{
+		Architecture: platform,
+		Size:         a.size,
+		PartSize:     partSize,
+		Checksum:     hex.EncodeToString(a.sha256),
+	})
+	if err != nil {
+		return fmt.Errorf("creating multipart upload: %w", err)
+	}
+	if mu.PartSize > 0 {
+		partSize = mu.PartSize
+	}
+	numParts := int((a.size + partSize - 1) / partSize)
+
+	urls := &partURLs{m: make(map[int]string, len(mu.Parts))}
+	for _, p := range mu.Parts {
+		urls.m[p.Number] = p.URL
+	}
+
+	pctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		parts    = make([]CompletedPart, numParts)
+		jobs     = make(chan int)
+	)
+	concurrency := u.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultUploadConcurrency
+	}
+	for w := 0; w < min(concurrency, numParts); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range jobs {
+				off := int64(n-1) * partSize
+				size := min(partSize, a.size-off)
+				cp, err := u.uploadPart(pctx, id, mu.ID, n, a.path, off, size, urls)
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("part %d: %w", n, err)
+						cancel()
+					})
+					continue
+				}
+				parts[n-1] = cp
+			}
+		}()
+	}
+feed:
+	for n := 1; n <= numParts; n++ {
+		select {
+		case jobs <- n:
+		case <-pctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr == nil {
+		firstErr = u.client.CompleteMultipartUpload(ctx, id, mu.ID, parts)
+	}
+	if firstErr != nil {
+		actx, acancel := context.WithTimeout(context.WithoutCancel(ctx), withdrawTimeout)
+		defer acancel()
+		_ = u.client.AbortMultipartUpload(actx, id, mu.ID)
+		return firstErr
+	}
+	return nil
+}
+
+// uploadPart uploads one part, refreshing its URL if it has expired.
+func (u *Uploader) uploadPart(ctx context.Context, id, uploadID string, n int, path string, off, size int64, urls *partURLs) (CompletedPart, error) {
+	sum, err := hashSection(path, off, size)
+	if err != nil {
+		return CompletedPart{}, err
+	}
+	refresh := func() error {
+		parts, err := u.client.RefreshPartURLs(ctx, id, uploadID, []int{n})
+		if err != nil {
+			return fmt.Errorf("refreshing part URL: %w", err)
+		}
+		for _, p := range parts {
+			urls.set(p.Number, p.URL)
+		}
+		return nil
+	}
+
+	var etag string
+	err = u.withRetry(ctx, func() (bool, error) {
+		target, ok := urls.get(n)
+		if !ok || presignedURLExpired(target) {
+			if err := refresh(); err != nil {
+				return false, err
+			}
+			if target, ok = urls.get(n); !ok {
+				return false, fmt.Errorf("no upload URL returned for part %d", n)
+			}
+		}
+		var (
+			retryable bool
+			err       error
+		)
+		etag, retryable, err = u.put(ctx, target, path, off, size, sum)
+		if errors.Is(err, ErrUploadURLExpired) {
+			if rerr := refresh(); rerr != nil {
+				return false, rerr
+			}
+		}
+		return retryable, err
+	})
+	if err != nil {
+		return CompletedPart{}, err
+	}
+	return CompletedPart{Number: n, ETag: etag, Checksum: base64.StdEncoding.EncodeToString(sum)}, nil
+}
+
+// withRetry runs op until it succeeds, returns a non-retryable error or the
+// retry policy is exhausted.
+func (u *Uploader) withRetry(ctx context.Context, op func() (retryable bool, err error)) error {
+	co := &callOptions{retry: &u.Retry}
+	attempts := max(u.Retry.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(co.backoff(attempt - 1)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		var retryable bool
+		retryable, err = op()
+		if err == nil || !retryable || ctx.Err() != nil {
+			break
+		}
+	}
+	return err
+}
+
+// put sends size bytes of the file at path, starting at off, to a presigned
+// URL and returns the ETag of the stored object or part.
+func (u *Uploader) put(ctx context.Context, target, path string, off, size int64, sum []byte) (etag string, retryable bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, fmt.Errorf("opening artifact: %w", err)
+	}
+	defer f.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, io.NewSectionReader(f, off, size))
+	if err != nil {
+		return "", false, fmt.Errorf("creating upload request: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("x-amz-checksum-sha256", base64.StdEncoding.EncodeToString(sum))
+	req.Header.Set("X-Checksum-Sha256", hex.EncodeToString(sum))
+
+	// Sending an artifact can take far longer than the client-wide timeout,
+	// so only ctx and a per-call timeout bound it.
+	hc := *u.client.httpClient
+	hc.Timeout = callOptionsFrom(ctx).timeout
+	resp, err := hc.Do(req)
+	if err != nil {
+		return "", true, fmt.Errorf("uploading artifact: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 300 {
+		if presignedResponseExpired(resp.StatusCode, body) {
+			return "", true, ErrUploadURLExpired
+		}
+		return "", retryableStatus(resp.StatusCode) || resp.StatusCode >= 500,
+			&APIError{StatusCode: resp.StatusCode, Message: "artifact upload failed"}
+	}
+	return resp.Header.Get("ETag"), false, nil
+}
+
+// presignedURLExpired reports whether a SigV4 presigned URL is past its
+// expiry. URLs without X-Amz-Date and X-Amz-Expires are assumed valid.
+func presignedURLExpired(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	q := u.Query()
+	signed, err := time.Parse("20060102T150405Z", q.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	secs, err := strconv.Atoi(q.Get("X-Amz-Expires"))
+	if err != nil {
+		return false
+	}
+	return !timeNow().Before(signed.Add(time.Duration(secs) * time.Second))
+}
+
+// presignedResponseExpired reports whether an S3-compatible error response
+// means the presigned URL has expired.
+func presignedResponseExpired(status int, body []byte) bool {
+	if status != http.StatusForbidden && status != http.StatusBadRequest {
+		return false
+	}
+	return bytes.Contains(body, []byte("Request has expired")) ||
+		bytes.Contains(body, []byte("<Code>ExpiredToken</Code>"))
+}
+
+// partURLs holds the current presigned URL of each part.
+type partURLs struct {
+	mu sync.Mutex
+	m  map[int]string
+}
+
+func (p *partURLs) get(n int) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	u, ok := p.m[n]
+	return u, ok
+}
+
+func (p *partURLs) set(n int, u string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.m[n] = u
+}
+
+// localArtifact is a packaged archive on disk with its precomputed digest.
+type localArtifact struct {
+	path   string
+	size   int64
+	sha256 []byte
+}
+
+func hashArtifact(path string) (*localArtifact, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening artifact: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return nil, fmt.Errorf("hashing artifact: %w", err)
+	}
+	return &localArtifact{path: path, size: n, sha256: h.Sum(nil)}, nil
+}
+
+func hashSection(path string, off, size int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening artifact: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(f, off, size)); err != nil {
+		return nil, fmt.Errorf("hashing artifact: %w", err)
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,306 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal S3-compatible stand-in. It stores objects and parts by
+// path, enforces SigV4-style expiry query parameters and checks the
+// Content-Length and x-amz-checksum-sha256 headers like the real service.
+type fakeS3 struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	puts    int
+	// failNext makes the next n PUTs fail with 503.
+	failNext int
+	// expireNext makes the next n PUTs fail as if the URL had expired.
+	expireNext int
+	// delay is how long each PUT takes to answer.
+	delay time.Duration
+}
+
+func newFakeS3(t *testing.T) *fakeS3 {
+	t.Helper()
+	s := &fakeS3{objects: map[string][]byte{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		time.Sleep(s.delay)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.puts++
+
+		if r.Header.Get("Authorization") != "" {
+			t.Error("credentials must not be sent to presigned URLs")
+		}
+		if s.expireNext > 0 || presignedURLExpired(r.URL.String()) {
+			if s.expireNext > 0 {
+				s.expireNext--
+			}
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, "<Error><Code>AccessDenied</Code><Message>Request has expired</Message></Error>")
+			return
+		}
+		if s.failNext > 0 {
+			s.failNext--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(body)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256(body)
+		if r.Header.Get("x-amz-checksum-sha256") != base64.StdEncoding.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, "<Error><Code>BadDigest</Code></Error>")
+			return
+		}
+		s.objects[r.URL.Path] = body
+		w.Header().Set("ETag", fmt.Sprintf("%q", fmt.Sprintf("%x", sum[:8])))
+	}))
+	return s
+}
+
+// presign returns a URL on s for path that expires at the given time.
+func (s *fakeS3) presign(path string, expires time.Time) string {
+	signed := expires.Add(-15 * time.Minute).UTC()
+	return fmt.Sprintf("%s%s?X-Amz-Date=%s&X-Amz-Expires=%d", s.URL, path, signed.Format("20060102T150405Z"), 15*60)
+}
+
+// fakeUploadAPI is the registry side of uploads: it presigns URLs on s3 and
+// assembles multipart uploads on completion.
+type fakeUploadAPI struct {
+	*httptest.Server
+	s3 *fakeS3
+
+	mu        sync.Mutex
+	expiry    time.Time // expiry of URLs handed out next
+	refreshes int
+	completed []byte
+	aborted   bool
+}
+
+func newFakeUploadAPI(t *testing.T, s3 *fakeS3) *fakeUploadAPI {
+	t.Helper()
+	f := &fakeUploadAPI{s3: s3, expiry: time.Now().Add(time.Hour)}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/submissions/sub-1/upload-urls", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.refreshes++
+		var req UploadURLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		urls := map[string]string{}
+		for _, p := range req.Architectures {
+			urls[p] = s3.presign("/bucket/sub-1/"+p+".tar.gz", f.expiry)
+		}
+		f.expiry = time.Now().Add(time.Hour)
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"urls": urls}})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/multipart-uploads", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var req CreateMultipartUploadRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		n := int((req.Size + req.PartSize - 1) / req.PartSize)
+		parts := make([]PresignedPart, n)
+		for i := range parts {
+			parts[i] = PresignedPart{Number: i + 1, URL: s3.presign(fmt.Sprintf("/bucket/mpu-1/%d", i+1), f.expiry)}
+		}
+		f.expiry = time.Now().Add(time.Hour)
+		writeJSON(w, map[string]interface{}{"success": true, "data": MultipartUpload{
+			ID: "mpu-1", Architecture: req.Architecture, PartSize: req.PartSize, Parts: parts,
+		}})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/multipart-uploads/mpu-1/part-urls", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.refreshes++
+		var req refreshPartURLsRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var parts []PresignedPart
+		for _, n := range req.PartNumbers {
+			parts = append(parts, PresignedPart{Number: n, URL: s3.presign(fmt.Sprintf("/bucket/mpu-1/%d", n), time.Now().Add(time.Hour))})
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"parts": parts}})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/multipart-uploads/mpu-1/complete", func(w http.ResponseWriter, r *http.Request) {
+		var req CompleteMultipartUploadRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		sort.Slice(req.Parts, func(i, j int) bool { return req.Parts[i].Number < req.Parts[j].Number })
+
+		s3.mu.Lock()
+		var buf bytes.Buffer
+		for _, p := range req.Parts {
+			if p.ETag == "" || p.Checksum == "" {
+				t.Errorf("part %d completed without etag or checksum", p.Number)
+			}
+			buf.Write(s3.objects["/bucket/mpu-1/"+strconv.Itoa(p.Number)])
+		}
+		s3.mu.Unlock()
+
+		f.mu.Lock()
+		f.completed = buf.Bytes()
+		f.mu.Unlock()
+		writeJSON(w, map[string]interface{}{"success": true})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/multipart-uploads/mpu-1", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.aborted = r.Method == http.MethodDelete
+		writeJSON(w, map[string]interface{}{"success": true})
+	})
+
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+func newTestUploader(t *testing.T) (*Uploader, *fakeUploadAPI, *fakeS3) {
+	t.Helper()
+	s3 := newFakeS3(t)
+	t.Cleanup(s3.Close)
+	api := newFakeUploadAPI(t, s3)
+	t.Cleanup(api.Close)
+
+	u := NewClient(WithBaseURL(api.URL), WithAPIKey("key")).NewUploader()
+	u.Retry = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	return u, api, s3
+}
+
+func TestUploader_single(t *testing.T) {
+	u, _, s3 := newTestUploader(t)
+	s3.failNext = 1
+
+	path := writeArtifact(t, "single part artifact")
+	if err := u.Upload(context.Background(), "sub-1", "linux_amd64", path); err != nil {
+		t.Fatalf("Upload() error: %v", err)
+	}
+	if got := string(s3.objects["/bucket/sub-1/linux_amd64.tar.gz"]); got != "single part artifact" {
+		t.Fatalf("unexpected object: %q", got)
+	}
+	if s3.puts != 2 {
+		t.Fatalf("expected one retry, got %d PUTs", s3.puts)
+	}
+}
+
+func TestUploader_ignoresClientTimeout(t *testing.T) {
+	s3 := newFakeS3(t)
+	defer s3.Close()
+	api := newFakeUploadAPI(t, s3)
+	defer api.Close()
+	s3.delay = 100 * time.Millisecond
+
+	c := NewClient(WithBaseURL(api.URL), WithAPIKey("key"), WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+	u := c.NewUploader()
+	u.Retry = RetryPolicy{MaxAttempts: 1}
+
+	path := writeArtifact(t, "slow artifact")
+	if err := u.Upload(context.Background(), "sub-1", "linux_amd64", path); err != nil {
+		t.Fatalf("Upload() error: %v", err)
+	}
+
+	ctx := WithCallOptions(context.Background(), CallTimeout(50*time.Millisecond))
+	if err := u.Upload(ctx, "sub-1", "linux_amd64", path); err == nil {
+		t.Fatal("expected CallTimeout to bound the upload")
+	}
+}
+
+func TestUploader_single_expiredURLRefreshed(t *testing.T) {
+	u, api, s3 := newTestUploader(t)
+
+	// The first URL is already expired; the second is rejected by the server
+	// as expired. Both must be replaced by fresh URLs.
+	api.expiry = time.Now().Add(-time.Minute)
+	s3.expireNext = 1
+	u.Retry.MaxAttempts = 2
+
+	path := writeArtifact(t, "artifact")
+	if err := u.Upload(context.Background(), "sub-1", "linux_amd64", path); err != nil {
+		t.Fatalf("Upload() error: %v", err)
+	}
+	if api.refreshes != 3 {
+		t.Fatalf("expected 3 URL requests, got %d", api.refreshes)
+	}
+	if s3.puts != 2 {
+		t.Fatalf("expected 2 PUTs, got %d", s3.puts)
+	}
+}
+
+func TestUploader_single_expiredExhausted(t *testing.T) {
+	u, _, s3 := newTestUploader(t)
+	s3.expireNext = 100
+
+	path := writeArtifact(t, "artifact")
+	err := u.Upload(context.Background(), "sub-1", "linux_amd64", path)
+	if !errors.Is(err, ErrUploadURLExpired) {
+		t.Fatalf("expected ErrUploadURLExpired, got %v", err)
+	}
+}
+
+func TestUploader_multipart(t *testing.T) {
+	u, api, s3 := newTestUploader(t)
+	u.MultipartThreshold = 16
+	u.PartSize = 5
+	u.Concurrency = 3
+	s3.failNext = 2
+	api.expiry = time.Now().Add(-time.Minute)
+
+	content := strings.Repeat("0123456789", 4) + "tail"
+	path := writeArtifact(t, content)
+	if err := u.Upload(context.Background(), "sub-1", "linux_amd64", path); err != nil {
+		t.Fatalf("Upload() error: %v", err)
+	}
+	if string(api.completed) != content {
+		t.Fatalf("assembled artifact mismatch: %q", api.completed)
+	}
+	if api.refreshes != 9 {
+		t.Fatalf("expected every expired part URL to be refreshed once (9), got %d", api.refreshes)
+	}
+	if api.aborted {
+		t.Fatal("successful upload should not abort")
+	}
+}
+
+func TestUploader_multipartFailureAborts(t *testing.T) {
+	u, api, s3 := newTestUploader(t)
+	u.MultipartThreshold = 16
+	u.PartSize = 5
+	s3.failNext = 1000
+
+	path := writeArtifact(t, strings.Repeat("x", 20))
+	err := u.Upload(context.Background(), "sub-1", "linux_amd64", path)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+	if !api.aborted {
+		t.Fatal("expected multipart upload to be aborted")
+	}
+	if api.completed != nil {
+		t.Fatal("failed upload must not be completed")
+	}
+}
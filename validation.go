@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Severity is the severity of a validation check.
+type Severity string
+
+const (
+	// SeverityError fails validation.
+	SeverityError Severity = "error"
+	// SeverityWarning is reported but does not fail validation.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo is informational.
+	SeverityInfo Severity = "info"
+)
+
+// ValidationCheck is a single finding from submission validation.
+type ValidationCheck struct {
+	RuleID       string   `json:"rule_id"`
+	Severity     Severity `json:"severity"`
+	Message      string   `json:"message"`
+	File         string   `json:"file,omitempty"`
+	Line         int      `json:"line,omitempty"`
+	Architecture string   `json:"architecture,omitempty"`
+}
+
+// Location returns the check's file location as "file" or "file:line", or
+// "" if it has none.
+func (c ValidationCheck) Location() string {
+	if c.File == "" {
+		return ""
+	}
+	if c.Line > 0 {
+		return fmt.Sprintf("%s:%d", c.File, c.Line)
+	}
+	return c.File
+}
+
+// ValidationReport is the structured result of validating a submission.
+// Checks apply to the submission as a whole; Architectures holds the checks
+// for each per-platform artifact.
+type ValidationReport struct {
+	Passed        bool                         `json:"passed"`
+	Checks        []ValidationCheck            `json:"checks,omitempty"`
+	Architectures map[string][]ValidationCheck `json:"architectures,omitempty"`
+}
+
+// ParseValidationReport decodes a validation result document. Each
+// architecture's checks have their Architecture field set from the map key.
+func ParseValidationReport(data []byte) (*ValidationReport, error) {
+	var r ValidationReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("decoding validation result: %w", err)
+	}
+	for arch, checks := range r.Architectures {
+		for i := range checks {
+			checks[i].Architecture = arch
+		}
+	}
+	return &r, nil
+}
+
+// All returns every check, submission-wide checks first and then each
+// architecture's checks in platform order.
+func (r *ValidationReport) All() []ValidationCheck {
+	all := append([]ValidationCheck(nil), r.Checks...)
+	for _, arch := range sortedKeys(r.Architectures) {
+		all = append(all, r.Architectures[arch]...)
+	}
+	return all
+}
+
+// Counts returns the number of error and warning checks.
+func (r *ValidationReport) Counts() (errs, warnings int) {
+	for _, c := range r.All() {
+		switch c.Severity {
+		case SeverityError:
+			errs++
+		case SeverityWarning:
+			warnings++
+		}
+	}
+	return errs, warnings
+}
+
+// HasErrors reports whether any check has error severity.
+func (r *ValidationReport) HasErrors() bool {
+	errs, _ := r.Counts()
+	return errs > 0
+}
+
+// Render writes r as a plain-text report suitable for CI logs.
+func (r *ValidationReport) Render(w io.Writer) error {
+	var b strings.Builder
+
+	errs, warnings := r.Counts()
+	verdict := "passed"
+	if !r.Passed || errs > 0 {
+		verdict = "failed"
+	}
+	fmt.Fprintf(&b, "Validation %s: %s, %s\n", verdict, plural(errs, "error"), plural(warnings, "warning"))
+
+	renderChecks(&b, "", r.Checks)
+	for _, arch := range sortedKeys(r.Architectures) {
+		fmt.Fprintf(&b, "\n%s:\n", arch)
+		renderChecks(&b, "  ", r.Architectures[arch])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// String returns the rendered report.
+func (r *ValidationReport) String() string {
+	var b strings.Builder
+	_ = r.Render(&b)
+	return b.String()
+}
+
+func renderChecks(b *strings.Builder, indent string, checks []ValidationCheck) {
+	for _, c := range checks {
+		fmt.Fprintf(b, "%s  [%s] %s: %s", indent, c.Severity, c.RuleID, c.Message)
+		if loc := c.Location(); loc != "" {
+			fmt.Fprintf(b, " (%s)", loc)
+		}
+		b.WriteByte('\n')
+	}
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// DecodeMetadata decodes the plugin metadata recorded with the submission.
+// It returns nil if the submission has no metadata yet.
+func (s *Submission) DecodeMetadata() (*PluginMeta, error) {
+	if strings.TrimSpace(s.Metadata) == "" {
+		return nil, nil
+	}
+	var meta PluginMeta
+	if err := json.Unmarshal([]byte(s.Metadata), &meta); err != nil {
+		return nil, fmt.Errorf("decoding submission metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// DecodeValidationResult decodes the submission's validation result. It
+// returns nil if validation has not produced a result yet.
+func (s *Submission) DecodeValidationResult() (*ValidationReport, error) {
+	if strings.TrimSpace(s.ValidationResult) == "" {
+		return nil, nil
+	}
+	return ParseValidationReport([]byte(s.ValidationResult))
+}
@@ -0,0 +1,81 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+const testValidationResult = `{
+	"passed": false,
+	"checks": [
+		{"rule_id": "manifest.version", "severity": "error", "message": "version does not match submission", "file": "plugin.yaml", "line": 2}
+	],
+	"architectures": {
+		"linux_amd64": [
+			{"rule_id": "binary.stripped", "severity": "warning", "message": "binary is not stripped", "file": "bin/plugin"}
+		],
+		"darwin_arm64": [
+			{"rule_id": "binary.present", "severity": "info", "message": "binary found"}
+		]
+	}
+}`
+
+func TestSubmission_DecodeValidationResult(t *testing.T) {
+	sub := &Submission{ValidationResult: testValidationResult}
+	r, err := sub.DecodeValidationResult()
+	if err != nil {
+		t.Fatalf("DecodeValidationResult() error: %v", err)
+	}
+	if r.Passed || !r.HasErrors() {
+		t.Fatal("expected failing report")
+	}
+	if errs, warnings := r.Counts(); errs != 1 || warnings != 1 {
+		t.Fatalf("expected 1 error and 1 warning, got %d and %d", errs, warnings)
+	}
+
+	all := r.All()
+	if len(all) != 3 || all[1].Architecture != "darwin_arm64" || all[2].Architecture != "linux_amd64" {
+		t.Fatalf("unexpected checks: %+v", all)
+	}
+	if loc := all[0].Location(); loc != "plugin.yaml:2" {
+		t.Fatalf("unexpected location: %s", loc)
+	}
+
+	want := `Validation failed: 1 error, 1 warning
+  [error] manifest.version: version does not match submission (plugin.yaml:2)
+
+darwin_arm64:
+    [info] binary.present: binary found
+
+linux_amd64:
+    [warning] binary.stripped: binary is not stripped (bin/plugin)
+`
+	if got := r.String(); got != want {
+		t.Fatalf("unexpected report:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSubmission_DecodeEmpty(t *testing.T) {
+	sub := &Submission{}
+	if r, err := sub.DecodeValidationResult(); r != nil || err != nil {
+		t.Fatalf("expected nil report, got %v, %v", r, err)
+	}
+	if m, err := sub.DecodeMetadata(); m != nil || err != nil {
+		t.Fatalf("expected nil metadata, got %v, %v", m, err)
+	}
+	sub.ValidationResult = "not json"
+	if _, err := sub.DecodeValidationResult(); err == nil || !strings.Contains(err.Error(), "validation result") {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+}
+
+func TestSubmission_DecodeMetadata(t *testing.T) {
+	sub := &Submission{Metadata: `{"id": "test-plugin", "version": "2.0.0", "name": "Test", "author": {"name": "Jo"}}`}
+	meta, err := sub.DecodeMetadata()
+	if err != nil {
+		t.Fatalf("DecodeMetadata() error: %v", err)
+	}
+	if meta.ID != "test-plugin" || meta.Version != "2.0.0" || meta.Author == nil || meta.Author.Name != "Jo" {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+}
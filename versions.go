@@ -27,3 +27,45 @@ func (c *Client) GetVersion(ctx context.Context, pluginID, version string) (*Plu
 	}
 	return &v, nil
 }
+
+// YankVersionRequest is the request body for yanking a version.
+type YankVersionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// VersionVisibilityRequest is the request body for changing a version's visibility.
+type VersionVisibilityRequest struct {
+	Visible bool `json:"visible"`
+}
+
+// YankVersion withdraws a version from resolution and update checks. Clients
+// that have it pinned in a lockfile can still install it. reason is shown to
+// anyone who requests the version.
+func (c *Client) YankVersion(ctx context.Context, pluginID, version, reason string) (*PluginVersion, error) {
+	var v PluginVersion
+	path := fmt.Sprintf("/v1/plugins/%s/versions/%s/yank", pluginID, version)
+	if err := c.post(ctx, path, &YankVersionRequest{Reason: reason}, &v); err != nil {
+		return nil, asPermissionError(err, "", pluginID, PermissionPublish)
+	}
+	return &v, nil
+}
+
+// UnyankVersion restores a yanked version.
+func (c *Client) UnyankVersion(ctx context.Context, pluginID, version string) (*PluginVersion, error) {
+	var v PluginVersion
+	path := fmt.Sprintf("/v1/plugins/%s/versions/%s/yank", pluginID, version)
+	if err := c.del(ctx, path, &v); err != nil {
+		return nil, asPermissionError(err, "", pluginID, PermissionPublish)
+	}
+	return &v, nil
+}
+
+// SetVersionVisibility shows or hides a version in listings and resolution.
+func (c *Client) SetVersionVisibility(ctx context.Context, pluginID, version string, visible bool) (*PluginVersion, error) {
+	var v PluginVersion
+	path := fmt.Sprintf("/v1/plugins/%s/versions/%s", pluginID, version)
+	if err := c.patch(ctx, path, &VersionVisibilityRequest{Visible: visible}, &v); err != nil {
+		return nil, asPermissionError(err, "", pluginID, PermissionPublish)
+	}
+	return &v, nil
+}
@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_YankVersion(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	v, err := c.YankVersion(ctx, "test-plugin", "1.1.0", "data loss bug")
+	if err != nil {
+		t.Fatalf("YankVersion() error: %v", err)
+	}
+	if !v.Yanked || v.YankReason != "data loss bug" {
+		t.Fatalf("expected yanked with reason, got %+v", v)
+	}
+
+	resolved, err := c.ResolveVersion(ctx, "test-plugin")
+	if err != nil {
+		t.Fatalf("ResolveVersion() error: %v", err)
+	}
+	if resolved.Version != "1.0.0" {
+		t.Fatalf("expected resolution to fall back to 1.0.0, got %s", resolved.Version)
+	}
+
+	v, err = c.UnyankVersion(ctx, "test-plugin", "1.1.0")
+	if err != nil {
+		t.Fatalf("UnyankVersion() error: %v", err)
+	}
+	if v.Yanked {
+		t.Fatal("expected version to be unyanked")
+	}
+}
+
+func TestClient_SetVersionVisibility(t *testing.T) {
+	srv := fakeVersionsAPI(t, testVersions())
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	v, err := c.SetVersionVisibility(ctx, "test-plugin", "1.3.0", true)
+	if err != nil {
+		t.Fatalf("SetVersionVisibility() error: %v", err)
+	}
+	if !v.Visible {
+		t.Fatal("expected version to be visible")
+	}
+
+	resolved, err := c.ResolveVersion(ctx, "test-plugin")
+	if err != nil {
+		t.Fatalf("ResolveVersion() error: %v", err)
+	}
+	if resolved.Version != "1.3.0" {
+		t.Fatalf("expected newly visible 1.3.0, got %s", resolved.Version)
+	}
+}
@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Polling bounds for WatchSubmission when the server does not stream events.
+// The interval grows while the status is unchanged and resets on a transition.
+var (
+	watchPollMin = 2 * time.Second
+	watchPollMax = 30 * time.Second
+)
+
+// watchMaxPollErrors is the number of consecutive failed polls after which
+// WatchSubmission gives up.
+const watchMaxPollErrors = 3
+
+// SubmissionEvent reports a submission status transition observed by
+// WatchSubmission. If Err is set the watch has failed and the channel is
+// closed after this event.
+type SubmissionEvent struct {
+	Submission *Submission
+	Status     SubmissionStatus
+	Previous   SubmissionStatus
+
+	ValidationStartedAt *time.Time
+	ReviewedAt          *time.Time
+	ReviewerNotes       string
+	// Validation is the decoded validation result, or nil if there is none
+	// yet or it could not be decoded.
+	Validation *ValidationReport
+
+	Err error
+}
+
+// WatchSubmission watches a submission until it reaches a terminal status or
+// ctx is done. The returned channel receives an event for the current status
+// and then one for every transition, and is closed when the watch ends.
+//
+// Server-Sent Events from /v1/submissions/{id}/events are used when the
+// server offers them; otherwise, or if the stream drops, the submission is
+// polled with an interval that backs off while nothing changes.
+func (c *Client) WatchSubmission(ctx context.Context, id string) (<-chan SubmissionEvent, error) {
+	sub, err := c.GetSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	w := &submissionWatcher{c: c, id: id, ch: make(chan SubmissionEvent, 1)}
+	go w.run(ctx, sub)
+	return w.ch, nil
+}
+
+type submissionWatcher struct {
+	c    *Client
+	id   string
+	ch   chan SubmissionEvent
+	last SubmissionStatus
+}
+
+func (w *submissionWatcher) run(ctx context.Context, sub *Submission) {
+	defer close(w.ch)
+
+	if done := w.emit(ctx, sub); done {
+		return
+	}
+	if done := w.stream(ctx); done || ctx.Err() != nil {
+		return
+	}
+	w.poll(ctx)
+}
+
+// emit sends an event if sub's status differs from the last one seen. It
+// reports whether the watch should stop.
+func (w *submissionWatcher) emit(ctx context.Context, sub *Submission) bool {
+	if sub.Status == w.last {
+		return sub.Status.IsTerminal()
+	}
+	ev := SubmissionEvent{
+		Submission:          sub,
+		Status:              sub.Status,
+		Previous:            w.last,
+		ValidationStartedAt: sub.ValidationStartedAt,
+		ReviewedAt:          sub.ReviewedAt,
+		ReviewerNotes:       sub.ReviewerNotes,
+	}
+	ev.Validation, _ = sub.DecodeValidationResult()
+	w.last = sub.Status
+
+	select {
+	case w.ch <- ev:
+	case <-ctx.Done():
+		return true
+	}
+	return sub.Status.IsTerminal()
+}
+
+// fail sends a final error event.
+func (w *submissionWatcher) fail(ctx context.Context, err error) {
+	select {
+	case w.ch <- SubmissionEvent{Status: w.last, Previous: w.last, Err: err}:
+	case <-ctx.Done():
+	}
+}
+
+// stream follows the server's event stream. It reports whether the watch is
+// done; false means the caller should fall back to polling.
+func (w *submissionWatcher) stream(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.c.baseURL+fmt.Sprintf("/v1/submissions/%s/events", w.id), nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	callOptionsFrom(ctx).applyHeaders(req)
+	if err := w.c.setAuthHeader(ctx, req); err != nil {
+		return false
+	}
+
+	// The stream is long-lived, so the client-wide timeout must not apply.
+	hc := *w.c.httpClient
+	hc.Timeout = 0
+	resp, err := hc.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt != "text/event-stream" {
+		return false
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	var (
+		event string
+		data  strings.Builder
+	)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 && (event == "" || event == "message" || event == "status") {
+				var sub Submission
+				if json.Unmarshal([]byte(data.String()), &sub) == nil && sub.Status != "" {
+					if w.emit(ctx, &sub) {
+						return true
+					}
+				}
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment, used by servers as a keep-alive.
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return false
+}
+
+// poll fetches the submission until it reaches a terminal status, backing
+// off while the status is unchanged.
+func (w *submissionWatcher) poll(ctx context.Context) {
+	interval := watchPollMin
+	failures := 0
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+
+		sub, err := w.c.GetSubmission(ctx, w.id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if failures++; failures >= watchMaxPollErrors {
+				w.fail(ctx, err)
+				return
+			}
+			continue
+		}
+		failures = 0
+
+		changed := sub.Status != w.last
+		if w.emit(ctx, sub) {
+			return
+		}
+		if changed {
+			interval = watchPollMin
+		} else {
+			interval = min(interval*3/2, watchPollMax)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fastWatchPoll shortens WatchSubmission's polling interval for the test.
+func fastWatchPoll(t *testing.T) {
+	t.Helper()
+	prevMin, prevMax := watchPollMin, watchPollMax
+	watchPollMin, watchPollMax = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() { watchPollMin, watchPollMax = prevMin, prevMax })
+}
+
+func collectEvents(t *testing.T, ch <-chan SubmissionEvent) []SubmissionEvent {
+	t.Helper()
+	var events []SubmissionEvent
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, ev)
+		case <-timeout:
+			t.Fatal("watch did not finish")
+		}
+	}
+}
+
+func eventStatuses(events []SubmissionEvent) []SubmissionStatus {
+	var s []SubmissionStatus
+	for _, ev := range events {
+		s = append(s, ev.Status)
+	}
+	return s
+}
+
+func TestClient_WatchSubmission_sse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/submissions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"id": "sub-1", "status": "validating"}})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected Accept header: %s", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		flusher := w.(http.Flusher)
+		for _, ev := range []string{
+			": keep-alive\n\n",
+			"event: status\ndata: {\"id\": \"sub-1\", \"status\": \"validating\"}\n\n",
+			"event: status\ndata: {\"id\": \"sub-1\", \"status\": \"pending_review\",\n" +
+				"data: \"validation_result\": \"{\\\"passed\\\": true}\"}\n\n",
+			"event: ping\ndata: {}\n\n",
+			"event: status\ndata: {\"id\": \"sub-1\", \"status\": \"rejected\", \"reviewer_notes\": \"missing icon\", \"reviewed_at\": \"2026-10-18T12:00:00Z\"}\n\n",
+		} {
+			fmt.Fprint(w, ev)
+			flusher.Flush()
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ch, err := c.WatchSubmission(context.Background(), "sub-1")
+	if err != nil {
+		t.Fatalf("WatchSubmission() error: %v", err)
+	}
+	events := collectEvents(t, ch)
+
+	got := eventStatuses(events)
+	want := []SubmissionStatus{SubmissionValidating, SubmissionPendingReview, SubmissionRejected}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if events[1].Validation == nil || !events[1].Validation.Passed {
+		t.Fatalf("expected decoded validation result, got %+v", events[1].Validation)
+	}
+	last := events[2]
+	if last.Previous != SubmissionPendingReview || last.ReviewerNotes != "missing icon" || last.ReviewedAt == nil {
+		t.Fatalf("unexpected final event: %+v", last)
+	}
+}
+
+func TestClient_WatchSubmission_pollingFallback(t *testing.T) {
+	fastWatchPoll(t)
+
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	statuses := []string{"validating", "validating", "pending_review", "pending_review", "approved", "published"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/submissions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"id": "sub-1", "status": status}})
+	})
+	mux.HandleFunc("/v1/submissions/sub-1/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ch, err := c.WatchSubmission(context.Background(), "sub-1")
+	if err != nil {
+		t.Fatalf("WatchSubmission() error: %v", err)
+	}
+	got := eventStatuses(collectEvents(t, ch))
+	want := []SubmissionStatus{SubmissionValidating, SubmissionPendingReview, SubmissionApproved, SubmissionPublished}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestClient_WatchSubmission_pollErrors(t *testing.T) {
+	fastWatchPoll(t)
+
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/submissions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		if polls > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			writeJSON(w, map[string]interface{}{"success": false, "message": "boom"})
+			return
+		}
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"id": "sub-1", "status": "validating"}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	ch, err := c.WatchSubmission(context.Background(), "sub-1")
+	if err != nil {
+		t.Fatalf("WatchSubmission() error: %v", err)
+	}
+	events := collectEvents(t, ch)
+	if len(events) != 2 {
+		t.Fatalf("expected initial and error events, got %+v", events)
+	}
+	var apiErr *APIError
+	if !errors.As(events[1].Err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected API error event, got %v", events[1].Err)
+	}
+	if polls != 1+watchMaxPollErrors {
+		t.Fatalf("expected %d polls, got %d", 1+watchMaxPollErrors, polls)
+	}
+}
+
+func TestClient_WatchSubmission_cancel(t *testing.T) {
+	fastWatchPoll(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/submissions/sub-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"success": true, "data": map[string]interface{}{"id": "sub-1", "status": "pending_review"}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := NewClient(WithBaseURL(srv.URL))
+	ch, err := c.WatchSubmission(ctx, "sub-1")
+	if err != nil {
+		t.Fatalf("WatchSubmission() error: %v", err)
+	}
+	if ev := <-ch; ev.Status != SubmissionPendingReview {
+		t.Fatalf("unexpected first event: %+v", ev)
+	}
+	cancel()
+	collectEvents(t, ch)
+}